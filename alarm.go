@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultAlarmOffset is used for events with no reminder overrides (Google)
+// or no VALARM (CalDAV). It defaults to matching Google Calendar's own
+// default of 10 minutes before the event starts, but is overridable with
+// --alarm-offset.
+var defaultAlarmOffset = 10 * time.Minute
+
+// snoozeDuration is how long snoozeAlarms defers re-firing an alarm, via
+// eventCacheDB.SnoozeAlarm.
+const snoozeDuration = 5 * time.Minute
+
+// alarmScanInterval is how often the alarm loop checks m.events for alarms
+// that have come due, both in the TUI (via tea.Tick) and in --daemon mode.
+const alarmScanInterval = 30 * time.Second
+
+// alarmTickMsg drives the recurring alarm scan; Update rearms it every time
+// it's handled, the same tea.Tick-based re-arming pattern the spinner
+// components use internally.
+type alarmTickMsg time.Time
+
+func alarmTick() tea.Cmd {
+	return tea.Tick(alarmScanInterval, func(t time.Time) tea.Msg {
+		return alarmTickMsg(t)
+	})
+}
+
+// dueAlarms returns the events in events whose alarm offset has come due as
+// of now and hasn't already fired (per eventCacheDB), one entry per
+// (event, offset) pair.
+func dueAlarms(events []*eventItem, now time.Time) []*eventItem {
+	var due []*eventItem
+	for _, ev := range events {
+		if ev.Declined() {
+			continue
+		}
+		for _, offset := range ev.AlarmOffsets {
+			fireAt := ev.Start.Add(-offset)
+			if now.Before(fireAt) || now.After(ev.Start) {
+				continue
+			}
+			if eventCacheDB.AlarmFired(ev.Id, offset, now) {
+				continue
+			}
+			due = append(due, ev)
+			_ = eventCacheDB.MarkAlarmFired(ev.Id, offset, now)
+			break
+		}
+	}
+	return due
+}
+
+// snoozeAlarms defers re-firing each of alarms' offsets by snoozeDuration,
+// rather than permanently suppressing them the way a normal fire
+// (MarkAlarmFired) does: the next scanForAlarms after the snooze expires
+// re-fires and re-shows the banner for these same events.
+func snoozeAlarms(alarms []*eventItem, now time.Time) {
+	for _, ev := range alarms {
+		for _, offset := range ev.AlarmOffsets {
+			_ = eventCacheDB.SnoozeAlarm(ev.Id, offset, now, snoozeDuration)
+		}
+	}
+}
+
+// fireAlarms notifies for each due event via notifier and returns the full
+// set, so the caller (TUI or daemon) can also show them on screen.
+func fireAlarms(notifier Notifier, events []*eventItem, now time.Time) []*eventItem {
+	due := dueAlarms(events, now)
+	for _, ev := range due {
+		body := fmt.Sprintf("%s - %s", ev.Start.Format("15:04"), ev.Summary)
+		_ = notifier.Notify("Upcoming event", body)
+	}
+	return due
+}
+
+// scanForAlarms is the tea.Cmd run on every alarmTickMsg: it fires any due
+// alarms against the currently loaded day's events and reports them back
+// to Update for the in-TUI "upcoming alarms" panel.
+func (m model) scanForAlarms() tea.Cmd {
+	events := m.events
+	return func() tea.Msg {
+		due := fireAlarms(notifier, events, time.Now())
+		return alarmsFiredMsg{alarms: due}
+	}
+}
+
+// alarmsFiredMsg reports the events whose alarms fired during the most
+// recent scan, for display in the upcoming-alarms panel.
+type alarmsFiredMsg struct {
+	alarms []*eventItem
+}
+
+// notifier is the process-wide Notifier used by both the TUI alarm loop and
+// --daemon mode; it's set once in main() alongside backend/eventCacheDB.
+var notifier Notifier
+
+// renderAlarmsPanel renders the banner listing events whose alarms have
+// fired and not yet been cleared -- either dismissed for good
+// (dismissAlarms) or deferred for snoozeDuration (snoozeAlarms) -- or ""
+// when there's nothing to show.
+func (m model) renderAlarmsPanel() string {
+	if len(m.firedAlarms) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(m.firedAlarms))
+	for i, ev := range m.firedAlarms {
+		lines[i] = fmt.Sprintf("%s - %s", ev.Start.Format("15:04"), ev.Summary)
+	}
+
+	s := "alarm: " + lines[0]
+	for _, line := range lines[1:] {
+		s += "; " + line
+	}
+	return alarmBannerStyle.Render(s)
+}
+
+var alarmBannerStyle = styles.Conflict
+
+// runDaemon runs headlessly: no TUI, just a loop that re-fetches today's
+// events and fires notifications for any alarm that comes due, reusing the
+// same backend/eventCacheDB/notifier main() already set up.
+func runDaemon() {
+	log.Printf("%s: running in daemon mode, scanning every %s", programName, alarmScanInterval)
+
+	for {
+		events, err := fetchEventsForDate(today())
+		if err != nil {
+			log.Printf("daemon: %v", err)
+		} else {
+			for _, ev := range fireAlarms(notifier, events, time.Now()) {
+				log.Printf("daemon: fired alarm for %q at %s", ev.Summary, ev.Start.Format("15:04"))
+			}
+		}
+
+		time.Sleep(alarmScanInterval)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestDueAlarms(t *testing.T) {
+	eventCacheDB = openTestCache(t)
+
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	ev := &eventItem{
+		Event:        &calendar.Event{Id: "1", Summary: "standup"},
+		Start:        now.Add(5 * time.Minute),
+		AlarmOffsets: []time.Duration{10 * time.Minute},
+	}
+
+	due := dueAlarms([]*eventItem{ev}, now)
+	if len(due) != 1 || due[0].Id != "1" {
+		t.Fatalf("expected the alarm to be due, got %+v", due)
+	}
+
+	// A second scan at the same moment shouldn't refire: MarkAlarmFired was
+	// recorded by the first dueAlarms call.
+	due = dueAlarms([]*eventItem{ev}, now)
+	if len(due) != 0 {
+		t.Fatalf("expected no alarms on the second scan, got %+v", due)
+	}
+}
+
+func TestDueAlarms_NotYetDue(t *testing.T) {
+	eventCacheDB = openTestCache(t)
+
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	ev := &eventItem{
+		Event:        &calendar.Event{Id: "2", Summary: "later"},
+		Start:        now.Add(1 * time.Hour),
+		AlarmOffsets: []time.Duration{10 * time.Minute},
+	}
+
+	if due := dueAlarms([]*eventItem{ev}, now); len(due) != 0 {
+		t.Fatalf("expected no alarms before the offset is reached, got %+v", due)
+	}
+}
+
+type fakeNotifier struct {
+	notified []string
+}
+
+func (f *fakeNotifier) Notify(title, body string) error {
+	f.notified = append(f.notified, body)
+	return nil
+}
+
+func TestFireAlarms(t *testing.T) {
+	eventCacheDB = openTestCache(t)
+
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	ev := &eventItem{
+		Event:        &calendar.Event{Id: "3", Summary: "1:1"},
+		Start:        now,
+		AlarmOffsets: []time.Duration{10 * time.Minute},
+	}
+
+	n := &fakeNotifier{}
+	due := fireAlarms(n, []*eventItem{ev}, now)
+	if len(due) != 1 || len(n.notified) != 1 {
+		t.Fatalf("expected one alarm to fire and be notified, got due=%+v notified=%v", due, n.notified)
+	}
+}
+
+func TestSnoozeAlarms(t *testing.T) {
+	eventCacheDB = openTestCache(t)
+
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	ev := &eventItem{
+		Event:        &calendar.Event{Id: "4", Summary: "standup"},
+		Start:        now.Add(10 * time.Minute),
+		AlarmOffsets: []time.Duration{10 * time.Minute},
+	}
+
+	due := dueAlarms([]*eventItem{ev}, now)
+	if len(due) != 1 {
+		t.Fatalf("expected the alarm to be due, got %+v", due)
+	}
+
+	snoozeAlarms(due, now)
+
+	if due := dueAlarms([]*eventItem{ev}, now.Add(1*time.Minute)); len(due) != 0 {
+		t.Fatalf("expected no alarms immediately after snoozing, got %+v", due)
+	}
+
+	// Past the snooze window, the alarm should refire even though it
+	// already fired once before being snoozed (a plain MarkAlarmFired,
+	// without a snooze, suppresses it for good instead -- see
+	// TestDueAlarms).
+	after := now.Add(snoozeDuration + time.Minute)
+	if due := dueAlarms([]*eventItem{ev}, after); len(due) != 1 || due[0].Id != "4" {
+		t.Fatalf("expected the alarm to refire after the snooze window, got %+v", due)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// attendeePatch describes the fields to change on the current user's
+// attendee entry. A nil Comment leaves the comment untouched; an empty
+// ResponseStatus leaves the response status untouched. ProposedStart and
+// ProposedEnd, when both non-nil, request a new time for the event instead
+// of responding to the existing one; support for this is backend-specific
+// (see each CalendarBackend's PatchAttendee), since it rides on the
+// attendee comment rather than a field the underlying API exposes.
+type attendeePatch struct {
+	ResponseStatus string
+	Comment        *string
+	ProposedStart  *time.Time
+	ProposedEnd    *time.Time
+}
+
+// CalendarBackend abstracts the calendar data source so the bubbletea layer
+// does not need to know whether events come from Google Calendar, CalDAV,
+// or any other provider. Implementations are responsible for converting
+// their native representation into *eventItem.
+type CalendarBackend interface {
+	// CalendarID identifies the calendar being read, used as the cache key
+	// prefix by eventCache.
+	CalendarID() string
+
+	// List returns events starting in [start, end), sorted by start time.
+	List(ctx context.Context, start, end time.Time) ([]*eventItem, error)
+
+	// PatchAttendee updates the current user's attendee entry on the event
+	// identified by id and returns the updated event.
+	PatchAttendee(ctx context.Context, id string, patch attendeePatch) (*eventItem, error)
+
+	// ListTasks returns all known tasks (VTODOs), regardless of due date;
+	// callers filter per day with filterTodosForDate since a repeating
+	// task's occurrences aren't enumerable without a window.
+	ListTasks(ctx context.Context) ([]*todoItem, error)
+
+	// CreateTask uploads a new task, assigning it a fresh UID.
+	CreateTask(ctx context.Context, t *todoItem) error
+
+	// CompleteTask marks t done. If t repeats, the backend instead advances
+	// it to its next occurrence rather than marking it done for good.
+	CompleteTask(ctx context.Context, t *todoItem) error
+
+	// DeleteTask removes the task identified by uid.
+	DeleteTask(ctx context.Context, uid string) error
+
+	// FreeBusy returns the busy intervals within [start, end) for the
+	// current user and the given attendee emails, used by :findtime to
+	// compute candidate meeting slots.
+	FreeBusy(ctx context.Context, attendees []string, start, end time.Time) ([]busyInterval, error)
+
+	// CreateEvent creates a new event from draft and returns it as stored
+	// by the backend (with an Id assigned).
+	CreateEvent(ctx context.Context, draft eventDraft) (*eventItem, error)
+
+	// PollChanges fetches events changed since syncToken using the
+	// backend's incremental sync API, for the background watch loop that
+	// keeps the TUI live between explicit reloads. An empty syncToken, or
+	// fullResync == true in the response, means the caller should discard
+	// syncToken and fall back to a full List instead of trying to apply a
+	// partial delta.
+	PollChanges(ctx context.Context, syncToken string) (events []*eventItem, nextSyncToken string, fullResync bool, err error)
+}
+
+// backend is the active CalendarBackend, selected in main() from the
+// --backend flag / credentials file. It is a package-level var, mirroring
+// how oauthClient is threaded through today.
+var backend CalendarBackend
+
+// newBackend constructs the CalendarBackend named by kind ("google",
+// "caldav" or "outlook") using the given credentials file. cfg.Calendars
+// selects which calendars it aggregates events from; an empty list means
+// just the account's primary/default calendar.
+func newBackend(ctx context.Context, kind, credentialsFile string, cfg uiConfig) (CalendarBackend, error) {
+	switch kind {
+	case "", "google":
+		return newGoogleBackend(ctx, credentialsFile, cfg)
+	case "caldav":
+		return newCalDAVBackend(ctx, credentialsFile, cfg)
+	case "outlook":
+		return newOutlookBackend(ctx, credentialsFile, cfg)
+	default:
+		return nil, fmt.Errorf("unknown backend: %q", kind)
+	}
+}
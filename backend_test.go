@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// fakeBackend is a minimal in-memory CalendarBackend used to exercise code
+// that depends on the CalendarBackend interface without talking to Google
+// or a CalDAV server.
+type fakeBackend struct {
+	events []*eventItem
+	todos  []*todoItem
+}
+
+var _ CalendarBackend = (*fakeBackend)(nil)
+
+func (f *fakeBackend) CalendarID() string {
+	return "fake"
+}
+
+func (f *fakeBackend) List(ctx context.Context, start, end time.Time) ([]*eventItem, error) {
+	var out []*eventItem
+	for _, ev := range f.events {
+		if !ev.Start.Before(end) && !ev.Start.Equal(start) {
+			continue
+		}
+		if ev.Start.Before(start) {
+			continue
+		}
+		out = append(out, ev)
+	}
+	markConflicts(out)
+	return out, nil
+}
+
+func (f *fakeBackend) PatchAttendee(ctx context.Context, id string, patch attendeePatch) (*eventItem, error) {
+	for _, ev := range f.events {
+		if ev.Id != id {
+			continue
+		}
+		if patch.ResponseStatus != "" {
+			ev.AttendeeStatus = patch.ResponseStatus
+		}
+		return ev, nil
+	}
+	return nil, errEventNotFound
+}
+
+func (f *fakeBackend) ListTasks(ctx context.Context) ([]*todoItem, error) {
+	return f.todos, nil
+}
+
+func (f *fakeBackend) CreateTask(ctx context.Context, t *todoItem) error {
+	if t.UID == "" {
+		t.UID = strconv.Itoa(len(f.todos) + 1)
+	}
+	f.todos = append(f.todos, t)
+	return nil
+}
+
+func (f *fakeBackend) CompleteTask(ctx context.Context, t *todoItem) error {
+	t.Completed = true
+	return nil
+}
+
+func (f *fakeBackend) DeleteTask(ctx context.Context, uid string) error {
+	for i, t := range f.todos {
+		if t.UID == uid {
+			f.todos = append(f.todos[:i], f.todos[i+1:]...)
+			return nil
+		}
+	}
+	return errEventNotFound
+}
+
+func (f *fakeBackend) FreeBusy(ctx context.Context, attendees []string, start, end time.Time) ([]busyInterval, error) {
+	var busy []busyInterval
+	for _, ev := range f.events {
+		if ev.Declined() {
+			continue
+		}
+		if ev.Start.Before(end) && start.Before(ev.End) {
+			busy = append(busy, busyInterval{Start: ev.Start, End: ev.End})
+		}
+	}
+	return busy, nil
+}
+
+func (f *fakeBackend) CreateEvent(ctx context.Context, draft eventDraft) (*eventItem, error) {
+	ev := &eventItem{
+		Event: &calendar.Event{Id: strconv.Itoa(len(f.events) + 1), Summary: draft.Summary},
+		Start: draft.Start,
+		End:   draft.End,
+	}
+	f.events = append(f.events, ev)
+	return ev, nil
+}
+
+func (f *fakeBackend) PollChanges(ctx context.Context, syncToken string) ([]*eventItem, string, bool, error) {
+	return nil, "", true, nil
+}
+
+var errEventNotFound = errors.New("event not found")
+
+func TestNewBackend_UnknownKind(t *testing.T) {
+	_, err := newBackend(context.Background(), "outlook-legacy", "/dev/null", uiConfig{})
+	if err == nil {
+		t.Fatal("expected an error for an unknown backend kind")
+	}
+}
+
+func TestFakeBackend_ListFiltersByRange(t *testing.T) {
+	day := 24 * time.Hour
+	base := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	inRange := &eventItem{Event: &calendar.Event{Id: "in"}, Start: base.Add(1 * time.Hour), End: base.Add(2 * time.Hour)}
+	outOfRange := &eventItem{Event: &calendar.Event{Id: "out"}, Start: base.Add(day + time.Hour), End: base.Add(day + 2*time.Hour)}
+
+	b := &fakeBackend{events: []*eventItem{inRange, outOfRange}}
+
+	events, err := b.List(context.Background(), base, base.Add(day))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Id != "in" {
+		t.Fatalf("expected only the in-range event, got %+v", events)
+	}
+}
+
+func TestFakeBackend_PatchAttendeeUpdatesStatus(t *testing.T) {
+	ev := &eventItem{Event: &calendar.Event{Id: "1"}, AttendeeStatus: "needsAction"}
+	b := &fakeBackend{events: []*eventItem{ev}}
+
+	updated, err := b.PatchAttendee(context.Background(), "1", attendeePatch{ResponseStatus: "accepted"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !updated.Accepted() {
+		t.Fatalf("expected event to be accepted, got status %q", updated.AttendeeStatus)
+	}
+}
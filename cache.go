@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+var (
+	bucketEvents     = []byte("events")
+	bucketSyncTokens = []byte("syncTokens")
+	bucketJournal    = []byte("journal")
+	bucketAlarms     = []byte("alarms")
+)
+
+// eventCache is an on-disk cache of events keyed by (calendarID, date), so
+// loadEvents can return instantly while a live refresh happens in the
+// background, and so the TUI stays usable (read-only) when offline.
+type eventCache struct {
+	db *bbolt.DB
+}
+
+func openEventCache(path string) (*eventCache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return nil, err
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{bucketEvents, bucketSyncTokens, bucketJournal, bucketAlarms} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &eventCache{db: db}, nil
+}
+
+func eventsCacheKey(calendarID string, date time.Time) []byte {
+	return []byte(calendarID + "|" + date.Format("2006-01-02"))
+}
+
+// Get returns the cached events for calendarID on date, reconstructing
+// eventItem (including conflict detection) from the stored raw
+// *calendar.Event values.
+func (c *eventCache) Get(calendarID string, date time.Time) ([]*eventItem, bool) {
+	var raw []*calendar.Event
+
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(bucketEvents).Get(eventsCacheKey(calendarID, date))
+		if v == nil {
+			return errCacheMiss
+		}
+		return json.Unmarshal(v, &raw)
+	})
+	if err != nil {
+		return nil, false
+	}
+
+	events := make([]*eventItem, 0, len(raw))
+	for _, it := range raw {
+		ev, err := eventFromRaw(it)
+		if err != nil {
+			continue
+		}
+		events = append(events, ev)
+	}
+	markConflicts(events)
+
+	return events, true
+}
+
+// Put stores events for calendarID on date, replacing whatever was cached
+// before.
+func (c *eventCache) Put(calendarID string, date time.Time, events []*eventItem) error {
+	raw := make([]*calendar.Event, len(events))
+	for i, ev := range events {
+		raw[i] = ev.Event
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketEvents).Put(eventsCacheKey(calendarID, date), b)
+	})
+}
+
+func (c *eventCache) SyncToken(calendarID string) string {
+	var token string
+	_ = c.db.View(func(tx *bbolt.Tx) error {
+		token = string(tx.Bucket(bucketSyncTokens).Get([]byte(calendarID)))
+		return nil
+	})
+	return token
+}
+
+func (c *eventCache) SetSyncToken(calendarID, token string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketSyncTokens).Put([]byte(calendarID), []byte(token))
+	})
+}
+
+// journalEntry is a queued write (attendee status/comment patch) that
+// couldn't reach the backend, to be replayed once connectivity returns.
+type journalEntry struct {
+	EventID string        `json:"eventId"`
+	Patch   attendeePatch `json:"patch"`
+}
+
+// EnqueueWrite journals a write that failed due to a network error.
+func (c *eventCache) EnqueueWrite(entry journalEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bucketJournal)
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		return bucket.Put(itob(seq), b)
+	})
+}
+
+// ReplayJournal applies every queued write via apply, removing each entry
+// on success and stopping at the first failure (so writes are replayed in
+// order and nothing is lost if connectivity drops again mid-replay).
+func (c *eventCache) ReplayJournal(apply func(journalEntry) error) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bucketJournal)
+		cur := bucket.Cursor()
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			var entry journalEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				_ = bucket.Delete(k)
+				continue
+			}
+			if err := apply(entry); err != nil {
+				return err
+			}
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// alarmSuppressForever is stored as the suppressed-until value by
+// MarkAlarmFired: an alarm that has simply fired (as opposed to being
+// snoozed) should never fire again, so it's suppressed far past any
+// event's lifetime rather than for a fixed duration.
+const alarmSuppressForever = 100 * 365 * 24 * time.Hour
+
+// AlarmFired reports whether an alarm for (eventID, offset) is currently
+// suppressed as of now: either because it already fired (MarkAlarmFired)
+// and hasn't been snoozed, or because an earlier SnoozeAlarm call's
+// duration hasn't elapsed yet. Once a snooze expires this returns false
+// again, so the next scan re-fires and re-notifies.
+func (c *eventCache) AlarmFired(eventID string, offset time.Duration, now time.Time) bool {
+	var suppressed bool
+	_ = c.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(bucketAlarms).Get(alarmKey(eventID, offset))
+		if v == nil {
+			return nil
+		}
+		until, err := time.Parse(time.RFC3339Nano, string(v))
+		if err != nil {
+			// Pre-snooze entries stored a single sentinel byte meaning
+			// "fired, suppressed forever" -- treat those the same way.
+			suppressed = true
+			return nil
+		}
+		suppressed = now.Before(until)
+		return nil
+	})
+	return suppressed
+}
+
+// MarkAlarmFired records that the alarm for (eventID, offset) fired at
+// now, suppressing it going forward (see AlarmFired) unless snoozed.
+func (c *eventCache) MarkAlarmFired(eventID string, offset time.Duration, now time.Time) error {
+	return c.setAlarmSuppressedUntil(eventID, offset, now.Add(alarmSuppressForever))
+}
+
+// SnoozeAlarm defers re-firing the alarm for (eventID, offset): it remains
+// suppressed until now.Add(d), then AlarmFired reports it due again for
+// the next scan to re-fire and re-notify, overriding any earlier
+// MarkAlarmFired suppression.
+func (c *eventCache) SnoozeAlarm(eventID string, offset time.Duration, now time.Time, d time.Duration) error {
+	return c.setAlarmSuppressedUntil(eventID, offset, now.Add(d))
+}
+
+func (c *eventCache) setAlarmSuppressedUntil(eventID string, offset time.Duration, until time.Time) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketAlarms).Put(alarmKey(eventID, offset), []byte(until.Format(time.RFC3339Nano)))
+	})
+}
+
+func alarmKey(eventID string, offset time.Duration) []byte {
+	return []byte(eventID + "|" + offset.String())
+}
+
+func (c *eventCache) Close() error {
+	return c.db.Close()
+}
+
+func itob(v uint64) []byte {
+	return []byte(fmt.Sprintf("%020d", v))
+}
+
+var errCacheMiss = errors.New("cache miss")
+
+// isNetworkError reports whether err looks like a connectivity problem
+// (DNS failure, connection refused/timeout, ...) as opposed to an API
+// error (bad request, auth failure, ...), so the caller knows it's safe to
+// fall back to the cache instead of treating it as fatal.
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	return false
+}
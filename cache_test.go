@@ -0,0 +1,93 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func openTestCache(t *testing.T) *eventCache {
+	t.Helper()
+
+	c, err := openEventCache(filepath.Join(t.TempDir(), "events.db"))
+	if err != nil {
+		t.Fatalf("openEventCache: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestEventCache_PutGetRoundTrip(t *testing.T) {
+	c := openTestCache(t)
+	date := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := c.Get("primary", date); ok {
+		t.Fatal("expected a cache miss before any Put")
+	}
+
+	events := []*eventItem{
+		{Event: &calendar.Event{Id: "1"}, Start: date.Add(1 * time.Hour), End: date.Add(2 * time.Hour)},
+	}
+	if err := c.Put("primary", date, events); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := c.Get("primary", date)
+	if !ok {
+		t.Fatal("expected a cache hit after Put")
+	}
+	if len(got) != 1 || got[0].Id != "1" {
+		t.Fatalf("expected the cached event back, got %+v", got)
+	}
+}
+
+func TestEventCache_SyncToken(t *testing.T) {
+	c := openTestCache(t)
+
+	if token := c.SyncToken("primary"); token != "" {
+		t.Fatalf("expected no sync token initially, got %q", token)
+	}
+
+	if err := c.SetSyncToken("primary", "abc123"); err != nil {
+		t.Fatalf("SetSyncToken: %v", err)
+	}
+	if token := c.SyncToken("primary"); token != "abc123" {
+		t.Fatalf("expected %q, got %q", "abc123", token)
+	}
+}
+
+func TestEventCache_ReplayJournal(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.EnqueueWrite(journalEntry{EventID: "1", Patch: attendeePatch{ResponseStatus: "accepted"}}); err != nil {
+		t.Fatalf("EnqueueWrite: %v", err)
+	}
+	if err := c.EnqueueWrite(journalEntry{EventID: "2", Patch: attendeePatch{ResponseStatus: "declined"}}); err != nil {
+		t.Fatalf("EnqueueWrite: %v", err)
+	}
+
+	var replayed []string
+	err := c.ReplayJournal(func(entry journalEntry) error {
+		replayed = append(replayed, entry.EventID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReplayJournal: %v", err)
+	}
+	if len(replayed) != 2 || replayed[0] != "1" || replayed[1] != "2" {
+		t.Fatalf("expected entries replayed in order, got %v", replayed)
+	}
+
+	replayed = nil
+	if err := c.ReplayJournal(func(entry journalEntry) error {
+		replayed = append(replayed, entry.EventID)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReplayJournal (second pass): %v", err)
+	}
+	if len(replayed) != 0 {
+		t.Fatalf("expected the journal to be empty after a successful replay, got %v", replayed)
+	}
+}
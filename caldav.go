@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// caldavBackend implements CalendarBackend against a generic CalDAV server
+// (Nextcloud, Fastmail, Radicale, ...), letting users without a Google
+// account use gcal-tui.
+type caldavBackend struct {
+	client *caldav.Client
+	// calendar is the one PatchAttendee/CreateEvent act against: there's
+	// no "primary calendar" concept in CalDAV, so it's just calendars[0].
+	calendar  *caldav.Calendar
+	calendars []*caldav.Calendar
+	selfEmail string
+}
+
+func newCalDAVBackend(ctx context.Context, credentialsFile string, cfg uiConfig) (CalendarBackend, error) {
+	caldavCfg, err := loadCalDAVConfig(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CalDAV config -- check README.md to get started: %w", err)
+	}
+
+	httpClient := webdav.HTTPClientWithBasicAuth(http.DefaultClient, caldavCfg.User, caldavCfg.Password)
+
+	client, err := caldav.NewClient(httpClient, caldavCfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	cals, err := client.FindCalendars(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list CalDAV calendars: %w", err)
+	}
+	if len(cals) == 0 {
+		return nil, fmt.Errorf("no calendars found at %s", caldavCfg.URL)
+	}
+
+	calendars := selectCalendars(cals, cfg.Calendars)
+
+	return &caldavBackend{
+		client:    client,
+		calendar:  calendars[0],
+		calendars: calendars,
+		selfEmail: caldavCfg.User,
+	}, nil
+}
+
+// selectCalendars filters cals down to the ones whose Path is in want,
+// preserving cals' order; an empty want (the common case: nothing
+// configured) means "just the first calendar the server reported",
+// preserving the pre-chunk1-2 single-calendar behavior.
+func selectCalendars(cals []caldav.Calendar, want []string) []*caldav.Calendar {
+	if len(want) == 0 {
+		return []*caldav.Calendar{&cals[0]}
+	}
+
+	wanted := make(map[string]bool, len(want))
+	for _, p := range want {
+		wanted[p] = true
+	}
+
+	var selected []*caldav.Calendar
+	for i := range cals {
+		if wanted[cals[i].Path] {
+			selected = append(selected, &cals[i])
+		}
+	}
+	if len(selected) == 0 {
+		return []*caldav.Calendar{&cals[0]}
+	}
+	return selected
+}
+
+func (c *caldavBackend) CalendarID() string {
+	return c.calendar.Path
+}
+
+// List fetches events across all of c.calendars and merges them sorted by
+// start time, tagging each with the calendar it came from. CalDAV has no
+// per-calendar color concept analogous to Google's colorId, so
+// CalendarColor is left empty.
+func (c *caldavBackend) List(ctx context.Context, start, end time.Time) ([]*eventItem, error) {
+	query := &caldav.CalendarQuery{
+		CompRequest: caldav.CalendarCompRequest{
+			Name:  "VCALENDAR",
+			Comps: []caldav.CalendarCompRequest{{Name: "VEVENT"}},
+		},
+		CompFilter: caldav.CompFilter{
+			Name: "VCALENDAR",
+			Comps: []caldav.CompFilter{{
+				Name:  "VEVENT",
+				Start: start,
+				End:   end,
+			}},
+		},
+	}
+
+	var events []*eventItem
+	for _, cal := range c.calendars {
+		objects, err := c.client.QueryCalendar(ctx, cal.Path, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range objects {
+			ev, err := c.eventFromObject(obj)
+			if err != nil {
+				continue
+			}
+			ev.CalendarID = cal.Path
+			events = append(events, ev)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	markConflicts(events)
+
+	return events, nil
+}
+
+func (c *caldavBackend) PatchAttendee(ctx context.Context, id string, patch attendeePatch) (*eventItem, error) {
+	if patch.ProposedStart != nil && patch.ProposedEnd != nil {
+		return nil, fmt.Errorf("proposing a new time is not supported by the caldav backend")
+	}
+
+	obj, err := c.client.GetCalendarObject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	vevent := obj.Data.Children[0]
+	for _, prop := range vevent.Props["ATTENDEE"] {
+		if prop.Params.Get("EMAIL") != c.selfEmail && prop.Value != "mailto:"+c.selfEmail {
+			continue
+		}
+		if patch.ResponseStatus != "" {
+			prop.Params.Set("PARTSTAT", toPartstat(patch.ResponseStatus))
+		}
+		if patch.Comment != nil {
+			vevent.Props.SetText(ical.PropComment, *patch.Comment)
+		}
+		break
+	}
+
+	if _, err := c.client.PutCalendarObject(ctx, obj.Path, obj.Data); err != nil {
+		return nil, err
+	}
+
+	return c.eventFromObject(*obj)
+}
+
+// eventFromObject converts a CalDAV VEVENT into the *eventItem shape used
+// by the rest of the TUI, reusing the Google calendar.Event struct as the
+// common in-memory representation.
+//
+// Id is set to obj.Path, the object's WebDAV href, not its iCalendar UID:
+// PatchAttendee passes eventItem.Id straight into GetCalendarObject and
+// PutCalendarObject, both of which treat it as a server-relative URL, and a
+// VEVENT's UID (e.g. "abc123@example.com") is not that URL.
+func (c *caldavBackend) eventFromObject(obj caldav.CalendarObject) (*eventItem, error) {
+	vevent := obj.Data.Children[0]
+
+	summary, _ := vevent.Props.Text(ical.PropSummary)
+
+	dtstart, err := vevent.Props.DateTime(ical.PropDateTimeStart, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	dtend, err := vevent.Props.DateTime(ical.PropDateTimeEnd, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := &calendar.Event{
+		Id:       obj.Path,
+		Summary:  summary,
+		HtmlLink: obj.Path,
+		Start:    &calendar.EventDateTime{DateTime: dtstart.Format(time.RFC3339)},
+		End:      &calendar.EventDateTime{DateTime: dtend.Format(time.RFC3339)},
+	}
+
+	for _, prop := range vevent.Props["ATTENDEE"] {
+		email := prop.Params.Get("EMAIL")
+		raw.Attendees = append(raw.Attendees, &calendar.EventAttendee{
+			Email:          email,
+			ResponseStatus: fromPartstat(prop.Params.Get("PARTSTAT")),
+			Self:           email == c.selfEmail,
+		})
+	}
+
+	event, err := eventFromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	event.AlarmOffsets = alarmOffsetsFromVALARMs(vevent)
+
+	return event, nil
+}
+
+// alarmOffsetsFromVALARMs reads each VALARM child's TRIGGER (a duration
+// relative to DTSTART) and returns how long before Start it fires,
+// mirroring the VALARM encoding vtodoFromTodo writes for tasks. A VEVENT
+// with no VALARM falls back to defaultAlarmOffset, same as Google events
+// with no reminder overrides.
+func alarmOffsetsFromVALARMs(vevent *ical.Component) []time.Duration {
+	var offsets []time.Duration
+	for _, child := range vevent.Children {
+		if child.Name != ical.CompAlarm {
+			continue
+		}
+		trigger, err := child.Props.Text(ical.PropTrigger)
+		if err != nil {
+			continue
+		}
+		d, err := parseICALDuration(trigger)
+		if err != nil {
+			continue
+		}
+		if d < 0 {
+			d = -d
+		}
+		offsets = append(offsets, d)
+	}
+	if len(offsets) == 0 {
+		return []time.Duration{defaultAlarmOffset}
+	}
+	return offsets
+}
+
+// formatICALDuration renders d as an RFC 5545 DURATION value (e.g. "-PT5M"
+// for 5 minutes, "P1DT2H" for a day and two hours), the format VALARM's
+// TRIGGER property expects. go-ical has no Duration/SetDuration helpers, so
+// this and parseICALDuration are the encode/decode pair vtodoFromTodo and
+// alarmOffsetsFromVALARMs share.
+func formatICALDuration(d time.Duration) string {
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	sb.WriteByte('P')
+	if days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		sb.WriteByte('T')
+		if hours > 0 {
+			fmt.Fprintf(&sb, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&sb, "%dM", minutes)
+		}
+		if seconds > 0 {
+			fmt.Fprintf(&sb, "%dS", seconds)
+		}
+	}
+	if sb.Len() == len(sign)+1 {
+		// Zero duration: P alone isn't valid, RFC 5545 requires at least
+		// one designator.
+		sb.WriteString("T0S")
+	}
+	return sb.String()
+}
+
+// parseICALDuration parses an RFC 5545 DURATION value, the inverse of
+// formatICALDuration. Only the day/hour/minute/second designators are
+// handled (no "W" weeks or "M" months-as-date-part, neither of which
+// vtodoFromTodo ever writes).
+func parseICALDuration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q: missing leading P", orig)
+	}
+	s = s[1:]
+
+	datePart, timePart := s, ""
+	if i := strings.IndexByte(s, 'T'); i >= 0 {
+		datePart, timePart = s[:i], s[i+1:]
+	}
+
+	var d time.Duration
+	num := ""
+	for _, r := range datePart {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'D':
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", orig, err)
+			}
+			d += time.Duration(n) * 24 * time.Hour
+			num = ""
+		default:
+			return 0, fmt.Errorf("invalid duration %q: unexpected %q in date part", orig, r)
+		}
+	}
+
+	num = ""
+	for _, r := range timePart {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'H', r == 'M', r == 'S':
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", orig, err)
+			}
+			switch r {
+			case 'H':
+				d += time.Duration(n) * time.Hour
+			case 'M':
+				d += time.Duration(n) * time.Minute
+			case 'S':
+				d += time.Duration(n) * time.Second
+			}
+			num = ""
+		default:
+			return 0, fmt.Errorf("invalid duration %q: unexpected %q in time part", orig, r)
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// toPartstat maps a Google Calendar responseStatus to the iCalendar
+// PARTSTAT value used by ATTENDEE properties.
+func toPartstat(status string) string {
+	switch status {
+	case "accepted":
+		return "ACCEPTED"
+	case "declined":
+		return "DECLINED"
+	case "tentative":
+		return "TENTATIVE"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// fromPartstat is the inverse of toPartstat.
+func fromPartstat(partstat string) string {
+	switch partstat {
+	case "ACCEPTED":
+		return "accepted"
+	case "DECLINED":
+		return "declined"
+	case "TENTATIVE":
+		return "tentative"
+	default:
+		return "needsAction"
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = programName + "-caldav"
+
+// calDAVConfig holds the connection details for a CalDAV backend. Password
+// is only populated from the credentials file as a fallback when the OS
+// keyring is unavailable; normally it is read from/written to the keyring
+// so it never has to sit in plaintext on disk.
+type calDAVConfig struct {
+	URL      string `json:"url"`
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+}
+
+// loadCalDAVConfig reads URL/user from credentialsFile and resolves the
+// password from the OS keyring, falling back to the (discouraged) plaintext
+// "password" field in the file when the keyring isn't usable.
+func loadCalDAVConfig(credentialsFile string) (*calDAVConfig, error) {
+	b, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg calDAVConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.URL == "" || cfg.User == "" {
+		return nil, fmt.Errorf("credentials file must set \"url\" and \"user\" for the caldav backend")
+	}
+
+	if password, err := keyring.Get(keyringService, cfg.User); err == nil {
+		cfg.Password = password
+		return &cfg, nil
+	}
+
+	if cfg.Password == "" {
+		return nil, fmt.Errorf("no password in OS keyring for %s/%s and none in credentials file", keyringService, cfg.User)
+	}
+
+	// Best-effort: migrate the plaintext password into the keyring so
+	// subsequent runs don't need it in the file.
+	_ = keyring.Set(keyringService, cfg.User, cfg.Password)
+
+	return &cfg, nil
+}
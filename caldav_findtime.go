@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/google/uuid"
+)
+
+// FreeBusy only reports the signed-in user's own busy time: unlike Google
+// Calendar, CalDAV has no single endpoint for looking up an arbitrary
+// attendee's free/busy state, only a per-server scheduling outbox that
+// go-webdav's client doesn't expose. Other attendees are therefore assumed
+// free; :findtime still narrows down a reasonable slot, it just can't rule
+// out conflicts on their side.
+func (c *caldavBackend) FreeBusy(ctx context.Context, attendees []string, start, end time.Time) ([]busyInterval, error) {
+	events, err := c.List(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]busyInterval, 0, len(events))
+	for _, ev := range events {
+		if ev.Declined() {
+			continue
+		}
+		busy = append(busy, busyInterval{Start: ev.Start, End: ev.End})
+	}
+	return busy, nil
+}
+
+func (c *caldavBackend) CreateEvent(ctx context.Context, draft eventDraft) (*eventItem, error) {
+	uid := uuid.NewString()
+
+	vevent := ical.NewComponent(ical.CompEvent)
+	vevent.Props.SetText(ical.PropUID, uid)
+	vevent.Props.SetText(ical.PropSummary, draft.Summary)
+	vevent.Props.SetDateTime(ical.PropDateTimeStart, draft.Start)
+	vevent.Props.SetDateTime(ical.PropDateTimeEnd, draft.End)
+
+	selfProp := ical.NewProp(ical.PropAttendee)
+	selfProp.Params.Set("EMAIL", c.selfEmail)
+	selfProp.Params.Set("PARTSTAT", "ACCEPTED")
+	selfProp.Value = "mailto:" + c.selfEmail
+	vevent.Props.Add(selfProp)
+
+	for _, email := range draft.Attendees {
+		prop := ical.NewProp(ical.PropAttendee)
+		prop.Params.Set("EMAIL", email)
+		prop.Params.Set("PARTSTAT", "NEEDS-ACTION")
+		prop.Value = "mailto:" + email
+		vevent.Props.Add(prop)
+	}
+
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//"+programName+"//EN")
+	cal.Children = append(cal.Children, vevent)
+
+	obj, err := c.client.PutCalendarObject(ctx, c.calendar.Path+uid+".ics", cal)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.eventFromObject(*obj)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/google/uuid"
+)
+
+func (c *caldavBackend) ListTasks(ctx context.Context) ([]*todoItem, error) {
+	query := &caldav.CalendarQuery{
+		CompRequest: caldav.CalendarCompRequest{
+			Name:  "VCALENDAR",
+			Comps: []caldav.CalendarCompRequest{{Name: "VTODO"}},
+		},
+		CompFilter: caldav.CompFilter{
+			Name:  "VCALENDAR",
+			Comps: []caldav.CompFilter{{Name: "VTODO"}},
+		},
+	}
+
+	objects, err := c.client.QueryCalendar(ctx, c.calendar.Path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*todoItem, 0, len(objects))
+	for _, obj := range objects {
+		t, err := todoFromVTODO(obj.Data.Children[0])
+		if err != nil {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (c *caldavBackend) CreateTask(ctx context.Context, t *todoItem) error {
+	if t.UID == "" {
+		t.UID = uuid.NewString()
+	}
+
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//"+programName+"//EN")
+	cal.Children = append(cal.Children, vtodoFromTodo(t))
+
+	_, err := c.client.PutCalendarObject(ctx, c.calendar.Path+t.UID+".ics", cal)
+	return err
+}
+
+func (c *caldavBackend) CompleteTask(ctx context.Context, t *todoItem) error {
+	obj, err := c.client.GetCalendarObject(ctx, taskObjectPath(c.calendar.Path, t.UID))
+	if err != nil {
+		return err
+	}
+
+	if r := t.rule(); r != nil {
+		next := t.nextOccurrence(t.DueDateTime)
+		if !next.IsZero() {
+			t.DueDateTime = next
+			obj.Data.Children[0].Props.SetDateTime(ical.PropDue, next)
+			_, err := c.client.PutCalendarObject(ctx, obj.Path, obj.Data)
+			return err
+		}
+	}
+
+	t.Completed = true
+	obj.Data.Children[0].Props.SetText(ical.PropStatus, "COMPLETED")
+	_, err = c.client.PutCalendarObject(ctx, obj.Path, obj.Data)
+	return err
+}
+
+func (c *caldavBackend) DeleteTask(ctx context.Context, uid string) error {
+	return c.client.RemoveAll(ctx, taskObjectPath(c.calendar.Path, uid))
+}
+
+func taskObjectPath(calendarPath, uid string) string {
+	return calendarPath + uid + ".ics"
+}
+
+func vtodoFromTodo(t *todoItem) *ical.Component {
+	vtodo := ical.NewComponent(ical.CompToDo)
+	vtodo.Props.SetText(ical.PropUID, t.UID)
+	vtodo.Props.SetText(ical.PropSummary, t.Name)
+	if t.Description != "" {
+		vtodo.Props.SetText(ical.PropDescription, t.Description)
+	}
+	if t.Priority != 0 {
+		vtodo.Props.SetText(ical.PropPriority, strconv.Itoa(t.Priority))
+	}
+	if !t.DueDateTime.IsZero() {
+		vtodo.Props.SetDateTime(ical.PropDue, t.DueDateTime)
+	}
+	if t.RRule != "" {
+		vtodo.Props.SetText(ical.PropRecurrenceRule, t.RRule)
+	}
+	if t.AlarmOffset > 0 {
+		alarm := ical.NewComponent(ical.CompAlarm)
+		alarm.Props.SetText(ical.PropAction, "DISPLAY")
+		alarm.Props.SetText(ical.PropDescription, t.Name)
+		alarm.Props.SetText(ical.PropTrigger, formatICALDuration(-t.AlarmOffset))
+		vtodo.Children = append(vtodo.Children, alarm)
+	}
+	vtodo.Props.SetText(ical.PropStatus, "NEEDS-ACTION")
+	return vtodo
+}
+
+func todoFromVTODO(vtodo *ical.Component) (*todoItem, error) {
+	uid, err := vtodo.Props.Text(ical.PropUID)
+	if err != nil {
+		return nil, err
+	}
+	name, _ := vtodo.Props.Text(ical.PropSummary)
+	desc, _ := vtodo.Props.Text(ical.PropDescription)
+	status, _ := vtodo.Props.Text(ical.PropStatus)
+
+	t := &todoItem{
+		UID:         uid,
+		Name:        name,
+		Description: desc,
+		Priority:    5,
+		Completed:   status == "COMPLETED",
+	}
+
+	if prop := vtodo.Props.Get(ical.PropPriority); prop != nil {
+		if p, err := strconv.Atoi(prop.Value); err == nil {
+			t.Priority = p
+		}
+	}
+	if due, err := vtodo.Props.DateTime(ical.PropDue, time.Local); err == nil {
+		t.DueDateTime = due
+	}
+	if rule, err := vtodo.Props.Text(ical.PropRecurrenceRule); err == nil {
+		t.RRule = rule
+	}
+
+	for _, child := range vtodo.Children {
+		if child.Name != ical.CompAlarm {
+			continue
+		}
+		trigger, err := child.Props.Text(ical.PropTrigger)
+		if err != nil {
+			continue
+		}
+		d, err := parseICALDuration(trigger)
+		if err != nil {
+			continue
+		}
+		if d < 0 {
+			d = -d
+		}
+		t.AlarmOffset = d
+		break
+	}
+
+	return t, nil
+}
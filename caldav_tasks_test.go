@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVTODOAlarmRoundTrip(t *testing.T) {
+	due := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	t1 := &todoItem{
+		UID:         "task-1",
+		Name:        "renew passport",
+		DueDateTime: due,
+		AlarmOffset: 5 * time.Minute,
+	}
+
+	vtodo := vtodoFromTodo(t1)
+
+	got, err := todoFromVTODO(vtodo)
+	if err != nil {
+		t.Fatalf("todoFromVTODO: %v", err)
+	}
+
+	if got.AlarmOffset != t1.AlarmOffset {
+		t.Errorf("AlarmOffset = %s, want %s", got.AlarmOffset, t1.AlarmOffset)
+	}
+	if got.Name != t1.Name {
+		t.Errorf("Name = %q, want %q", got.Name, t1.Name)
+	}
+	if !got.DueDateTime.Equal(t1.DueDateTime) {
+		t.Errorf("DueDateTime = %s, want %s", got.DueDateTime, t1.DueDateTime)
+	}
+}
+
+func TestVTODONoAlarm(t *testing.T) {
+	t1 := &todoItem{UID: "task-2", Name: "no alarm here"}
+
+	vtodo := vtodoFromTodo(t1)
+
+	got, err := todoFromVTODO(vtodo)
+	if err != nil {
+		t.Fatalf("todoFromVTODO: %v", err)
+	}
+	if got.AlarmOffset != 0 {
+		t.Errorf("AlarmOffset = %s, want 0", got.AlarmOffset)
+	}
+}
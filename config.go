@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// uiConfig holds settings that aren't secrets (unlike credentials.json /
+// the CalDAV keyring entry) and so are safe to read and rewrite freely:
+// which calendars to aggregate events from, and which of those the user
+// has temporarily hidden from the list.
+type uiConfig struct {
+	// Calendars lists the calendar IDs (Google calendarId, or CalDAV
+	// calendar path) to fetch events from. Empty means "just the
+	// account's primary/default calendar", preserving the pre-chunk1-2
+	// single-calendar behavior.
+	Calendars []string `json:"calendars,omitempty"`
+
+	// HiddenCalendars lists calendar IDs to fetch but not show, toggled
+	// at runtime with the toggleCalendar key.
+	HiddenCalendars []string `json:"hiddenCalendars,omitempty"`
+}
+
+func configFilePath(confDir string) string {
+	return filepath.Join(confDir, programName, "config.json")
+}
+
+// loadUIConfig reads uiConfig from path, returning a zero-value uiConfig
+// (not an error) when the file doesn't exist yet, matching the "first run,
+// nothing configured" case.
+func loadUIConfig(path string) (uiConfig, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return uiConfig{}, nil
+	}
+	if err != nil {
+		return uiConfig{}, err
+	}
+
+	var cfg uiConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return uiConfig{}, err
+	}
+	return cfg, nil
+}
+
+// save writes cfg back to path, creating its parent directory if needed.
+func (cfg uiConfig) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// hiddenSet returns HiddenCalendars as a lookup set.
+func (cfg uiConfig) hiddenSet() map[string]bool {
+	hidden := make(map[string]bool, len(cfg.HiddenCalendars))
+	for _, id := range cfg.HiddenCalendars {
+		hidden[id] = true
+	}
+	return hidden
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadUIConfig_MissingFile(t *testing.T) {
+	cfg, err := loadUIConfig(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("loadUIConfig: %v", err)
+	}
+	if len(cfg.Calendars) != 0 || len(cfg.HiddenCalendars) != 0 {
+		t.Fatalf("expected a zero-value uiConfig, got %+v", cfg)
+	}
+}
+
+func TestUIConfig_SaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcal-tui", "config.json")
+
+	cfg := uiConfig{
+		Calendars:       []string{"primary", "team@example.com"},
+		HiddenCalendars: []string{"team@example.com"},
+	}
+	if err := cfg.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, err := loadUIConfig(path)
+	if err != nil {
+		t.Fatalf("loadUIConfig: %v", err)
+	}
+	if !reflect.DeepEqual(got, cfg) {
+		t.Fatalf("expected %+v, got %+v", cfg, got)
+	}
+}
+
+func TestUIConfig_HiddenSet(t *testing.T) {
+	cfg := uiConfig{HiddenCalendars: []string{"a", "b"}}
+	set := cfg.hiddenSet()
+	if !set["a"] || !set["b"] || set["c"] {
+		t.Fatalf("unexpected hidden set: %+v", set)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import "time"
+
+// busyInterval is a [Start, End) span during which an attendee is busy, as
+// returned by CalendarBackend.FreeBusy.
+type busyInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// eventDraft describes a new event to create via CalendarBackend.CreateEvent.
+type eventDraft struct {
+	Summary        string
+	Start          time.Time
+	End            time.Time
+	Attendees      []string
+	ConferenceData bool
+}
+
+// timeSlot is a candidate meeting slot of the requested duration, free for
+// every attendee.
+type timeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+const (
+	findTimeDefaultDuration = 30 * time.Minute
+	findTimeWorkdays        = 5
+	findTimeDayStartHour    = 9
+	findTimeDayEndHour      = 18
+)
+
+// findTimeHorizon returns the next findTimeWorkdays workdays after from
+// (from itself included if it's already a workday), used as the default
+// :findtime search horizon.
+func findTimeHorizon(from time.Time) []time.Time {
+	days := make([]time.Time, 0, findTimeWorkdays)
+
+	d := truncateToDay(from)
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.Add(day)
+	}
+
+	days = append(days, d)
+	for len(days) < findTimeWorkdays {
+		d = nextWeekday(d)
+		days = append(days, d)
+	}
+	return days
+}
+
+// candidateSlots scans workdays between findTimeDayStartHour and
+// findTimeDayEndHour (in loc) for gaps of at least duration that don't
+// overlap any interval in busy, returning them in chronological order.
+func candidateSlots(workdays []time.Time, busy []busyInterval, duration time.Duration, loc *time.Location) []timeSlot {
+	var slots []timeSlot
+
+	for _, wd := range workdays {
+		windowStart := time.Date(wd.Year(), wd.Month(), wd.Day(), findTimeDayStartHour, 0, 0, 0, loc)
+		windowEnd := time.Date(wd.Year(), wd.Month(), wd.Day(), findTimeDayEndHour, 0, 0, 0, loc)
+
+		cursor := windowStart
+		for cursor.Add(duration).Before(windowEnd) || cursor.Add(duration).Equal(windowEnd) {
+			candidateEnd := cursor.Add(duration)
+
+			if blockedUntil, blocked := blockingEnd(cursor, candidateEnd, busy); blocked {
+				cursor = blockedUntil
+				continue
+			}
+
+			slots = append(slots, timeSlot{Start: cursor, End: candidateEnd})
+			cursor = candidateEnd
+		}
+	}
+
+	return slots
+}
+
+// blockingEnd reports whether any busy interval overlaps [start, end), and
+// if so the latest End among them, so candidateSlots can jump straight past
+// a long conflicting meeting instead of re-testing one minute at a time.
+func blockingEnd(start, end time.Time, busy []busyInterval) (time.Time, bool) {
+	var latest time.Time
+	blocked := false
+	for _, b := range busy {
+		if start.Before(b.End) && b.Start.Before(end) {
+			blocked = true
+			if b.End.After(latest) {
+				latest = b.End
+			}
+		}
+	}
+	return latest, blocked
+}
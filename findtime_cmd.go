@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type freeTimeSlotsMsg struct {
+	slots []timeSlot
+}
+
+type eventCreatedMsg struct {
+	summary string
+}
+
+// parseFindTimeInput splits "alice@x.com,bob@x.com 30m" into the attendee
+// list and the requested slot duration; a missing or unparsable trailing
+// duration falls back to findTimeDefaultDuration.
+func parseFindTimeInput(value string) ([]string, time.Duration) {
+	fields := strings.Fields(value)
+
+	duration := findTimeDefaultDuration
+	emailsPart := value
+	if len(fields) > 1 {
+		if d, err := time.ParseDuration(fields[len(fields)-1]); err == nil {
+			duration = d
+			emailsPart = strings.Join(fields[:len(fields)-1], " ")
+		}
+	}
+
+	var attendees []string
+	for _, email := range strings.Split(emailsPart, ",") {
+		email = strings.TrimSpace(email)
+		if email != "" {
+			attendees = append(attendees, email)
+		}
+	}
+
+	return attendees, duration
+}
+
+// submitFindTimeInput parses the attendee/duration form and kicks off the
+// free/busy query over the default horizon (findTimeHorizon from m.date).
+func (m model) submitFindTimeInput() (model, tea.Cmd) {
+	attendees, duration := parseFindTimeInput(m.inputFindTime.Value())
+	if len(attendees) == 0 {
+		m.errorMessage = "enter at least one attendee email"
+		return m, nil
+	}
+
+	m.findTimeAttendees = attendees
+	return m, m.findFreeTime(attendees, duration)
+}
+
+func (m model) findFreeTime(attendees []string, duration time.Duration) tea.Cmd {
+	workdays := findTimeHorizon(m.date)
+	rangeStart := workdays[0]
+	rangeEnd := workdays[len(workdays)-1].Add(day)
+
+	return func() tea.Msg {
+		busy, err := backend.FreeBusy(context.Background(), attendees, rangeStart, rangeEnd)
+		if err != nil {
+			return nonFatalErrorMsg{errorMessage: "Failed to query free/busy: " + err.Error()}
+		}
+
+		slots := candidateSlots(workdays, busy, duration, time.Local)
+		return freeTimeSlotsMsg{slots: slots}
+	}
+}
+
+// enterCreateEventMode opens the "create event" form for the slot currently
+// selected in findTimeList.
+func (m model) enterCreateEventMode() (model, tea.Cmd) {
+	slot, ok := m.findTimeList.SelectedItem().(timeSlot)
+	if !ok {
+		m.errorMessage = "no slot selected"
+		return m, nil
+	}
+
+	m.selectedSlot = slot
+	m.uiMode = uiModeCreateEvent
+	m.inputEventSummary.SetValue("")
+	m.inputEventSummary.Focus()
+	return m, textinput.Blink
+}
+
+func (m model) createEventForSelectedSlot(summary string) tea.Cmd {
+	draft := eventDraft{
+		Summary:        summary,
+		Start:          m.selectedSlot.Start,
+		End:            m.selectedSlot.End,
+		Attendees:      m.findTimeAttendees,
+		ConferenceData: true,
+	}
+
+	return func() tea.Msg {
+		if _, err := backend.CreateEvent(context.Background(), draft); err != nil {
+			return nonFatalErrorMsg{errorMessage: "Failed to create event: " + err.Error()}
+		}
+		return eventCreatedMsg{summary: summary}
+	}
+}
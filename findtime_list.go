@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (s timeSlot) FilterValue() string {
+	return s.Start.Format(time.RFC3339)
+}
+
+// findTimeListDelegate renders ranked timeSlot candidates in findTimeList,
+// mirroring tasksListDelegate's single-line style.
+type findTimeListDelegate struct{}
+
+var _ list.ItemDelegate = (*findTimeListDelegate)(nil)
+
+func (d *findTimeListDelegate) Height() int  { return 1 }
+func (d *findTimeListDelegate) Spacing() int { return 0 }
+
+func (d *findTimeListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	s := item.(timeSlot)
+
+	line := fmt.Sprintf("%s  %s-%s", s.Start.Format("Mon 01-02"), s.Start.Format("15:04"), s.End.Format("15:04"))
+	if index == m.Index() {
+		line = styles.SelectedTitle.Inline(true).Render(line)
+	}
+
+	fmt.Fprint(w, line)
+}
+
+func (d *findTimeListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
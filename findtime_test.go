@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindTimeHorizon(t *testing.T) {
+	days := findTimeHorizon(newDate(2024, time.March, 7)) // Thursday
+	if len(days) != findTimeWorkdays {
+		t.Fatalf("expected %d days, got %d", findTimeWorkdays, len(days))
+	}
+	for _, d := range days {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			t.Fatalf("expected only weekdays, got %s", d.Weekday())
+		}
+	}
+	if !days[0].Equal(newDate(2024, time.March, 7)) {
+		t.Fatalf("expected the horizon to start on the given weekday, got %s", days[0])
+	}
+}
+
+func TestCandidateSlots_SkipsBusyIntervals(t *testing.T) {
+	day1 := newDate(2024, time.March, 4) // Monday
+	busy := []busyInterval{
+		{Start: time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC), End: time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)},
+	}
+
+	slots := candidateSlots([]time.Time{day1}, busy, 30*time.Minute, time.UTC)
+	if len(slots) == 0 {
+		t.Fatal("expected at least one free slot")
+	}
+	for _, s := range slots {
+		if s.Start.Before(busy[0].End) && busy[0].Start.Before(s.End) {
+			t.Fatalf("slot %v-%v overlaps busy interval %v-%v", s.Start, s.End, busy[0].Start, busy[0].End)
+		}
+	}
+	if !slots[0].Start.Equal(busy[0].End) {
+		t.Fatalf("expected the first free slot to start right after the busy interval, got %s", slots[0].Start)
+	}
+}
+
+func TestParseFindTimeInput(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		expectedAttendee []string
+		expectedDuration time.Duration
+	}{
+		{"emails and duration", "alice@example.com, bob@example.com 45m", []string{"alice@example.com", "bob@example.com"}, 45 * time.Minute},
+		{"emails only, default duration", "alice@example.com,bob@example.com", []string{"alice@example.com", "bob@example.com"}, findTimeDefaultDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendees, duration := parseFindTimeInput(tt.value)
+			if !reflect.DeepEqual(attendees, tt.expectedAttendee) {
+				t.Errorf("attendees = %v, want %v", attendees, tt.expectedAttendee)
+			}
+			if duration != tt.expectedDuration {
+				t.Errorf("duration = %v, want %v", duration, tt.expectedDuration)
+			}
+		})
+	}
+}
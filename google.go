@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+	tasks "google.golang.org/api/tasks/v1"
+)
+
+// googleBackend implements CalendarBackend against the Google Calendar API
+// (and, for tasks, the Google Tasks API). It is the original, and default,
+// backend.
+type googleBackend struct {
+	client     *calendar.Service
+	calendarID string
+
+	// calendarIDs is the full set of calendars List/PollChanges aggregate
+	// events from (config-driven, chunk1-2); calendarID above stays the
+	// first of these and remains what PatchAttendee/CalendarID/PollChanges
+	// use, since attendee patches and incremental sync only make sense
+	// against a single calendar at a time.
+	calendarIDs []string
+	// colorByID is a best-effort calendarId -> "#rrggbb" lookup, populated
+	// from CalendarList.List at construction so List can tint each event
+	// by the calendar it came from.
+	colorByID map[string]string
+
+	tasksClient *tasks.Service
+	taskListID  string
+}
+
+func newGoogleBackend(ctx context.Context, credentialsFile string, cfg uiConfig) (CalendarBackend, error) {
+	httpClient, err := getGoogleOAuthClient(credentialsFile, []string{
+		calendar.CalendarEventsScope,
+		tasks.TasksScope,
+	})
+	if err != nil {
+		return nil, err
+	}
+	oauthClient = httpClient
+
+	client, err := calendar.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve Calendar client: %w", err)
+	}
+
+	tasksClient, err := tasks.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve Tasks client: %w", err)
+	}
+
+	calendarIDs := cfg.Calendars
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{"primary"}
+	}
+
+	colorByID := map[string]string{}
+	if list, err := client.CalendarList.List().Context(ctx).Do(); err == nil {
+		for _, entry := range list.Items {
+			colorByID[entry.Id] = entry.BackgroundColor
+		}
+	}
+
+	return &googleBackend{
+		client:      client,
+		calendarID:  calendarIDs[0],
+		calendarIDs: calendarIDs,
+		colorByID:   colorByID,
+		tasksClient: tasksClient,
+		taskListID:  "@default",
+	}, nil
+}
+
+func (g *googleBackend) CalendarID() string {
+	return g.calendarID
+}
+
+// List fetches events in [start, end) directly, rather than through
+// eventCache's SyncToken/SetSyncToken: the Calendar API rejects a syncToken
+// combined with TimeMin/TimeMax, and List is always called with a specific
+// day's window. The sync token storage is there for a future whole-calendar
+// sync path; loadEvents gets its offline/incremental behavior from the
+// per-day cache.Put instead.
+//
+// When more than one calendar is configured (chunk1-2), List fetches each
+// one and merges the results, sorted by start time, tagging every event
+// with the calendar it came from so the list delegate can tint it and the
+// visibility toggle can filter by it. Conflict detection then runs across
+// the merged set, not per calendar.
+func (g *googleBackend) List(ctx context.Context, start, end time.Time) ([]*eventItem, error) {
+	var events []*eventItem
+
+	for _, calendarID := range g.calendarIDs {
+		eventsListResult, err := g.client.Events.List(calendarID).
+			Context(ctx).
+			ShowDeleted(false).
+			SingleEvents(true).
+			TimeMin(start.Format(time.RFC3339)).
+			TimeMax(end.Format(time.RFC3339)).
+			OrderBy("startTime").
+			Do()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, it := range eventsListResult.Items {
+			if it.Start.DateTime == "" || it.End.DateTime == "" {
+				continue
+			}
+
+			event, err := eventFromRaw(it)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", it.Summary, err)
+			}
+
+			event.CalendarID = calendarID
+			event.CalendarColor = g.colorByID[calendarID]
+
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	markConflicts(events)
+
+	return events, nil
+}
+
+func (g *googleBackend) PatchAttendee(ctx context.Context, id string, patch attendeePatch) (*eventItem, error) {
+	ev, err := g.client.Events.Get(g.calendarID, id).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range ev.Attendees {
+		if !a.Self {
+			continue
+		}
+		if patch.ResponseStatus != "" {
+			a.ResponseStatus = patch.ResponseStatus
+		}
+		if patch.Comment != nil {
+			a.Comment = *patch.Comment
+		}
+		if patch.ProposedStart != nil && patch.ProposedEnd != nil {
+			a.Comment = formatProposedTimeComment(*patch.ProposedStart, *patch.ProposedEnd)
+		}
+		break
+	}
+
+	rawEv, err := g.client.Events.Patch(g.calendarID, id, &calendar.Event{
+		Attendees: ev.Attendees,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return eventFromRaw(rawEv)
+}
+
+// proposedTimeCommentPrefix marks an attendee comment as carrying a
+// proposed new time (see proposeNewTime in main.go). The Calendar API's
+// typed EventAttendee has no ProposedNewTime-style field to set instead -
+// proposing a new time isn't part of the public Calendar API at all, only
+// of Gmail's/Outlook's own UI - so the comment is the closest honest
+// approximation available over this API.
+const proposedTimeCommentPrefix = "[proposed new time] "
+
+// formatProposedTimeComment encodes start/end as a proposedTimeCommentPrefix
+// comment.
+func formatProposedTimeComment(start, end time.Time) string {
+	return fmt.Sprintf("%s%s - %s", proposedTimeCommentPrefix, start.Format(time.RFC3339), end.Format(time.RFC3339))
+}
+
+// parseProposedTimeComment decodes a comment written by
+// formatProposedTimeComment, used by eventItem.ProposedTime.
+func parseProposedTimeComment(comment string) (start, end time.Time, ok bool) {
+	if !strings.HasPrefix(comment, proposedTimeCommentPrefix) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(comment, proposedTimeCommentPrefix), " - ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err = time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, end, true
+}
+
+// eventFromRaw converts a raw *calendar.Event (as returned by Patch/Get)
+// into the *eventItem shape used by the rest of the TUI.
+func eventFromRaw(raw *calendar.Event) (*eventItem, error) {
+	event := eventItem{Event: raw}
+
+	if raw.Start != nil && raw.Start.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, raw.Start.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		event.Start = t
+	}
+	if raw.End != nil && raw.End.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, raw.End.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		event.End = t
+	}
+
+	event.AttendeeStatus = "unknown"
+	for _, a := range raw.Attendees {
+		if a.Self {
+			event.AttendeeStatus = a.ResponseStatus
+			break
+		}
+	}
+	if event.AttendeeStatus == "unknown" && raw.Creator != nil && raw.Creator.Self {
+		event.AttendeeStatus = "accepted"
+	}
+
+	event.AlarmOffsets = alarmOffsetsFromReminders(raw.Reminders)
+
+	return &event, nil
+}
+
+// alarmOffsetsFromReminders converts a Google Calendar EventReminders into
+// the offsets-before-Start the alarm subsystem fires at, falling back to
+// defaultAlarmOffset when the event has no specific overrides.
+func alarmOffsetsFromReminders(r *calendar.EventReminders) []time.Duration {
+	if r == nil || len(r.Overrides) == 0 {
+		return []time.Duration{defaultAlarmOffset}
+	}
+
+	offsets := make([]time.Duration, 0, len(r.Overrides))
+	for _, o := range r.Overrides {
+		offsets = append(offsets, time.Duration(o.Minutes)*time.Minute)
+	}
+	return offsets
+}
+
+// markConflicts annotates each non-declined event in events with the other
+// non-declined events it overlaps, mirroring the conflict detection that
+// used to live inline in loadEvents/fetchEventsForDate.
+func markConflicts(events []*eventItem) {
+	for _, ev := range events {
+		if ev.Declined() {
+			continue
+		}
+		for _, ev2 := range events {
+			if ev2.Declined() {
+				continue
+			}
+			if ev.Id == ev2.Id {
+				continue
+			}
+			if ev.intersectsWith(ev2) {
+				ev.ConflictsWith = append(ev.ConflictsWith, ev2)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func (g *googleBackend) FreeBusy(ctx context.Context, attendees []string, start, end time.Time) ([]busyInterval, error) {
+	items := make([]*calendar.FreeBusyRequestItem, 0, len(attendees)+1)
+	items = append(items, &calendar.FreeBusyRequestItem{Id: g.calendarID})
+	for _, email := range attendees {
+		items = append(items, &calendar.FreeBusyRequestItem{Id: email})
+	}
+
+	resp, err := g.client.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: start.Format(time.RFC3339),
+		TimeMax: end.Format(time.RFC3339),
+		Items:   items,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("freebusy query: %w", err)
+	}
+
+	var busy []busyInterval
+	for id, cal := range resp.Calendars {
+		if len(cal.Errors) > 0 {
+			return nil, fmt.Errorf("freebusy query for %s: %s", id, cal.Errors[0].Reason)
+		}
+		for _, b := range cal.Busy {
+			bstart, err := time.Parse(time.RFC3339, b.Start)
+			if err != nil {
+				return nil, err
+			}
+			bend, err := time.Parse(time.RFC3339, b.End)
+			if err != nil {
+				return nil, err
+			}
+			busy = append(busy, busyInterval{Start: bstart, End: bend})
+		}
+	}
+
+	return busy, nil
+}
+
+func (g *googleBackend) CreateEvent(ctx context.Context, draft eventDraft) (*eventItem, error) {
+	ev := &calendar.Event{
+		Summary: draft.Summary,
+		Start:   &calendar.EventDateTime{DateTime: draft.Start.Format(time.RFC3339)},
+		End:     &calendar.EventDateTime{DateTime: draft.End.Format(time.RFC3339)},
+	}
+	for _, email := range draft.Attendees {
+		ev.Attendees = append(ev.Attendees, &calendar.EventAttendee{Email: email})
+	}
+
+	call := g.client.Events.Insert(g.calendarID, ev).Context(ctx)
+	if draft.ConferenceData {
+		ev.ConferenceData = &calendar.ConferenceData{
+			CreateRequest: &calendar.CreateConferenceRequest{
+				RequestId:             fmt.Sprintf("%s-%d", draft.Summary, draft.Start.Unix()),
+				ConferenceSolutionKey: &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+			},
+		}
+		call = call.ConferenceDataVersion(1)
+	}
+
+	rawEv, err := call.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return eventFromRaw(rawEv)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tasks "google.golang.org/api/tasks/v1"
+)
+
+// Google Tasks has no native notion of priority, RRULE or an alarm offset,
+// so those fields only exist in memory for the Google backend: they're
+// lost across restarts. Completing a repeating task still only marks it
+// done, since the occurrence can't be advanced server-side either.
+
+func (g *googleBackend) ListTasks(ctx context.Context) ([]*todoItem, error) {
+	result, err := g.tasksClient.Tasks.List(g.taskListID).Context(ctx).ShowCompleted(true).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*todoItem, 0, len(result.Items))
+	for _, it := range result.Items {
+		t, err := todoFromGoogleTask(it)
+		if err != nil {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (g *googleBackend) CreateTask(ctx context.Context, t *todoItem) error {
+	raw := &tasks.Task{
+		Title: t.Name,
+		Notes: t.Description,
+	}
+	if !t.DueDateTime.IsZero() {
+		raw.Due = t.DueDateTime.Format(time.RFC3339)
+	}
+
+	created, err := g.tasksClient.Tasks.Insert(g.taskListID, raw).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	t.UID = created.Id
+	return nil
+}
+
+func (g *googleBackend) CompleteTask(ctx context.Context, t *todoItem) error {
+	if r := t.rule(); r != nil {
+		next := t.nextOccurrence(t.DueDateTime)
+		if !next.IsZero() {
+			t.DueDateTime = next
+			_, err := g.tasksClient.Tasks.Patch(g.taskListID, t.UID, &tasks.Task{
+				Due: next.Format(time.RFC3339),
+			}).Context(ctx).Do()
+			return err
+		}
+	}
+
+	t.Completed = true
+	_, err := g.tasksClient.Tasks.Patch(g.taskListID, t.UID, &tasks.Task{
+		Status: "completed",
+	}).Context(ctx).Do()
+	return err
+}
+
+func (g *googleBackend) DeleteTask(ctx context.Context, uid string) error {
+	return g.tasksClient.Tasks.Delete(g.taskListID, uid).Context(ctx).Do()
+}
+
+func todoFromGoogleTask(raw *tasks.Task) (*todoItem, error) {
+	t := &todoItem{
+		UID:         raw.Id,
+		Name:        raw.Title,
+		Description: raw.Notes,
+		Priority:    5,
+		Completed:   raw.Status == "completed",
+	}
+	if raw.Due != "" {
+		due, err := time.Parse(time.RFC3339, raw.Due)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", raw.Title, err)
+		}
+		t.DueDateTime = due
+	}
+	return t, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// PollChanges fetches events changed since syncToken via the Calendar
+// API's incremental sync (Events.List with SyncToken), paging through
+// NextPageToken until NextSyncToken is returned. ShowDeleted is set so a
+// cancelled event surfaces with Status == "cancelled" instead of vanishing
+// silently. A 410 Gone response means the token expired server-side, so the
+// caller should fall back to a full resync rather than treating it as a
+// hard error.
+func (g *googleBackend) PollChanges(ctx context.Context, syncToken string) ([]*eventItem, string, bool, error) {
+	if syncToken == "" {
+		return g.bootstrapSyncToken(ctx)
+	}
+
+	var events []*eventItem
+	nextToken := ""
+	pageToken := ""
+
+	for {
+		call := g.client.Events.List(g.calendarID).
+			Context(ctx).
+			ShowDeleted(true).
+			SyncToken(syncToken)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusGone {
+				return nil, "", true, nil
+			}
+			return nil, "", false, err
+		}
+
+		for _, it := range result.Items {
+			ev, err := eventFromRaw(it)
+			if err != nil {
+				continue
+			}
+			events = append(events, ev)
+		}
+
+		if result.NextPageToken == "" {
+			nextToken = result.NextSyncToken
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	return events, nextToken, false, nil
+}
+
+// bootstrapSyncToken mints a starting sync token by paging through every
+// event on the calendar: Events.List rejects SyncToken combined with
+// TimeMin/TimeMax (see List's own doc comment), so an initial, unbounded
+// List is the only way to obtain one. Without this, PollChanges would see
+// an empty syncToken forever and report fullResync on every single poll,
+// rather than just this one first time. No events are reported changed -
+// the current day is already loaded via the normal List path - so the
+// caller doesn't reset the list cursor for what amounts to bookkeeping.
+func (g *googleBackend) bootstrapSyncToken(ctx context.Context) ([]*eventItem, string, bool, error) {
+	nextToken := ""
+	pageToken := ""
+
+	for {
+		call := g.client.Events.List(g.calendarID).
+			Context(ctx).
+			ShowDeleted(true).
+			SingleEvents(true)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		if result.NextPageToken == "" {
+			nextToken = result.NextSyncToken
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	return nil, nextToken, false, nil
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
-	"net/http/httptest"
 	"os"
 	"path/filepath"
 
@@ -47,43 +50,154 @@ func getHTTPClient(config *oauth2.Config) (*http.Client, error) {
 	return config.Client(context.Background(), token), nil
 }
 
-func startCodeReceiver() (<-chan string, string, func()) {
-	ch := make(chan string)
+// loopbackPortRange bounds the ports doAuthorization tries to bind the
+// redirect listener to. Google's "Desktop app" OAuth client type accepts
+// any http://127.0.0.1:<port>/ or http://localhost:<port>/ redirect URI,
+// so a fixed range to try in order is enough without registering one
+// exact port.
+var loopbackPortRange = [2]int{8730, 8749}
+
+// listenLoopback binds the first free port in loopbackPortRange on
+// 127.0.0.1, trying each in turn since another local process (or a
+// previous run that didn't clean up yet) may be holding one.
+func listenLoopback() (net.Listener, error) {
+	for port := loopbackPortRange[0]; port <= loopbackPortRange[1]; port++ {
+		l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("doAuthorization: no free port in %d-%d for the OAuth redirect listener", loopbackPortRange[0], loopbackPortRange[1])
+}
+
+// startCodeReceiver binds a loopback listener and serves it until a
+// request carrying a matching state arrives, then sends its code on the
+// returned channel. A state mismatch (or no code at all) is rejected
+// rather than silently ignored, since with a real listener (unlike
+// httptest's random port) anything on the machine can now reach it.
+func startCodeReceiver(state string) (<-chan string, string, func(), error) {
+	listener, err := listenLoopback()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	ch := make(chan string, 1)
 
-	s := httptest.NewServer(
-		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.URL.Path == "/favicon.ico" {
-				http.Error(w, "Not Found", 404)
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+
+			if r.FormValue("state") != state {
+				http.Error(w, "state mismatch", http.StatusBadRequest)
 				return
 			}
 
-			if code := r.FormValue("code"); code != "" {
-				w.Header().Set("Content-Type", "text/plain")
-				fmt.Fprintln(w, "Authorized.")
-				ch <- code
+			code := r.FormValue("code")
+			if code == "" {
+				http.Error(w, "missing code", http.StatusBadRequest)
 				return
 			}
-		}))
 
-	return ch, s.URL, func() { s.Close() }
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, authSuccessHTML)
+			ch <- code
+		}),
+	}
+	go server.Serve(listener)
+
+	redirectURL := fmt.Sprintf("http://%s/", listener.Addr().String())
+
+	return ch, redirectURL, func() { server.Close() }, nil
+}
+
+// authSuccessHTML is served in place of the old plain-text "Authorized."
+// response: it tells the user to close the tab and closes it itself after
+// a moment, since window.close() only works on a tab opened by script in
+// some browsers.
+const authSuccessHTML = `<!DOCTYPE html>
+<html><head><title>Authorized</title></head>
+<body onload="setTimeout(function(){window.close()}, 1000)">
+<p>Authorized. You can close this window.</p>
+</body></html>`
+
+// newPKCEPair generates a PKCE code_verifier and its S256 code_challenge
+// (RFC 7636), protecting the loopback redirect against a malicious local
+// process that captures the authorization code but doesn't have the
+// verifier needed to exchange it.
+func newPKCEPair() (verifier, challenge string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(raw)
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return verifier, challenge, nil
+}
+
+// randomState generates the value sent as the OAuth "state" parameter and
+// checked back on the redirect, so an authorization response isn't
+// accepted unless it's for the request that just started.
+func randomState() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
 }
 
 func doAuthorization(config *oauth2.Config) (*oauth2.Token, error) {
-	ch, url, cancel := startCodeReceiver()
+	verifier, challenge, err := newPKCEPair()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	ch, redirectURL, cancel, err := startCodeReceiver(state)
+	if err != nil {
+		return nil, err
+	}
 	defer cancel()
 
-	config.RedirectURL = url
+	config.RedirectURL = redirectURL
 
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	authURL := config.AuthCodeURL(state,
+		oauth2.AccessTypeOffline,
+		oauth2.SetAuthURLParam("code_challenge", challenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
 	fmt.Printf("Visit below to authorize %s:\n%s\n", program, authURL)
 
 	authCode := <-ch
 
-	return config.Exchange(context.TODO(), authCode)
+	return config.Exchange(context.TODO(), authCode,
+		oauth2.SetAuthURLParam("code_verifier", verifier),
+	)
 }
 
 func restoreToken() (*oauth2.Token, error) {
-	f, err := os.Open(tokenFile)
+	return restoreTokenAt(tokenFile)
+}
+
+func storeToken(token *oauth2.Token) error {
+	return storeTokenAt(tokenFile, token)
+}
+
+// restoreTokenAt and storeTokenAt are the path-parameterized versions of
+// restoreToken/storeToken, shared with other providers (e.g. the outlook
+// backend's own token cache) that need their own file under cacheDir so
+// switching --backend doesn't clobber another provider's token.
+func restoreTokenAt(path string) (*oauth2.Token, error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +208,13 @@ func restoreToken() (*oauth2.Token, error) {
 	return &token, err
 }
 
-func storeToken(token *oauth2.Token) error {
-	err := os.MkdirAll(filepath.Dir(tokenFile), 0o777)
+func storeTokenAt(path string, token *oauth2.Token) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o777)
 	if err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(tokenFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
 		return err
 	}
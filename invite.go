@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// inviteReplyOptions configures handleInviteMode, populated from the
+// --invite/--response/--email/--sendmail flags.
+type inviteReplyOptions struct {
+	file           string
+	responseStatus string // "accepted", "tentative" or "declined"
+	selfEmail      string
+	sendmail       bool
+	sync           bool
+}
+
+// handleInviteMode reads a text/calendar METHOD:REQUEST payload from
+// opts.file, builds the matching METHOD:REPLY with the user's PARTSTAT set,
+// and writes it to stdout (or pipes it to sendmail when opts.sendmail is
+// set). When opts.sync is set and a backend is configured, it also tries
+// to apply the same response to the matching Google/CalDAV event.
+func handleInviteMode(opts inviteReplyOptions) error {
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return fmt.Errorf("unable to read invite file: %w", err)
+	}
+	defer f.Close()
+
+	cal, err := ical.NewDecoder(f).Decode()
+	if err != nil {
+		return fmt.Errorf("unable to parse invite: %w", err)
+	}
+
+	vevent := cal.Events()
+	if len(vevent) == 0 {
+		return fmt.Errorf("no VEVENT found in %s", opts.file)
+	}
+	req := &vevent[0]
+
+	uid, err := req.Props.Text(ical.PropUID)
+	if err != nil {
+		return fmt.Errorf("invite is missing UID: %w", err)
+	}
+
+	reply, err := buildInviteReply(req, uid, opts.selfEmail, opts.responseStatus)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := ical.NewEncoder(&buf).Encode(reply); err != nil {
+		return fmt.Errorf("unable to encode reply: %w", err)
+	}
+
+	if opts.sendmail {
+		if err := pipeToSendmail(buf.Bytes()); err != nil {
+			return err
+		}
+	} else {
+		if _, err := io.Copy(os.Stdout, &buf); err != nil {
+			return err
+		}
+	}
+
+	if opts.sync && backend != nil {
+		_, err := backend.PatchAttendee(context.Background(), uid, attendeePatch{ResponseStatus: opts.responseStatus})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not sync response to calendar (UID %s): %v\n", uid, err)
+		}
+	}
+
+	return nil
+}
+
+// buildInviteReply produces the METHOD:REPLY calendar for req, copying
+// UID+SEQUENCE+ORGANIZER+DTSTART/DTEND+SUMMARY and including only the
+// self attendee, with PARTSTAT set to responseStatus.
+func buildInviteReply(req *ical.Event, uid, selfEmail, responseStatus string) (*ical.Calendar, error) {
+	reply := ical.NewCalendar()
+	reply.Props.SetText(ical.PropVersion, "2.0")
+	reply.Props.SetText(ical.PropProductID, "-//"+programName+"//EN")
+	reply.Props.SetText(ical.PropMethod, "REPLY")
+
+	vevent := ical.NewEvent()
+	vevent.Props.SetText(ical.PropUID, uid)
+	vevent.Props.SetDateTime(ical.PropDateTimeStamp, time.Now().UTC())
+
+	if prop := req.Props.Get(ical.PropSequence); prop != nil {
+		vevent.Props.Set(prop)
+	}
+	if prop := req.Props.Get(ical.PropOrganizer); prop != nil {
+		vevent.Props.Set(prop)
+	}
+	if prop := req.Props.Get(ical.PropDateTimeStart); prop != nil {
+		vevent.Props.Set(prop)
+	}
+	if prop := req.Props.Get(ical.PropDateTimeEnd); prop != nil {
+		vevent.Props.Set(prop)
+	}
+	if summary, err := req.Props.Text(ical.PropSummary); err == nil {
+		vevent.Props.SetText(ical.PropSummary, summary)
+	}
+
+	partstat := toPartstat(responseStatus)
+	attendee := ical.NewProp(ical.PropAttendee)
+	attendee.Params.Set("PARTSTAT", partstat)
+	attendee.Value = "mailto:" + selfEmail
+	vevent.Props.Set(attendee)
+
+	reply.Children = append(reply.Children, vevent.Component)
+
+	return reply, nil
+}
+
+// pipeToSendmail writes body to a `sendmail -t` subprocess so it's mailed
+// directly to the addresses named in its headers/To-equivalent ATTENDEE.
+func pipeToSendmail(body []byte) error {
+	cmd := exec.Command("sendmail", "-t")
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
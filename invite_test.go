@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/emersion/go-ical"
+)
+
+func TestBuildInviteReply(t *testing.T) {
+	req := ical.NewEvent()
+	req.Props.SetText(ical.PropSummary, "Planning sync")
+	req.Props.SetText(ical.PropSequence, "2")
+
+	reply, err := buildInviteReply(req, "event-uid-1", "me@example.com", "tentative")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := reply.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected exactly one VEVENT in the reply, got %d", len(events))
+	}
+
+	uid, err := events[0].Props.Text(ical.PropUID)
+	if err != nil || uid != "event-uid-1" {
+		t.Errorf("expected UID to be copied, got %q (err %v)", uid, err)
+	}
+
+	method, err := reply.Props.Text(ical.PropMethod)
+	if err != nil || method != "REPLY" {
+		t.Errorf("expected METHOD:REPLY, got %q (err %v)", method, err)
+	}
+
+	attendee := events[0].Props.Get(ical.PropAttendee)
+	if attendee == nil {
+		t.Fatal("expected an ATTENDEE property in the reply")
+	}
+	if got := attendee.Params.Get("PARTSTAT"); got != "TENTATIVE" {
+		t.Errorf("expected PARTSTAT=TENTATIVE, got %q", got)
+	}
+}
@@ -16,6 +16,8 @@ type eventsListStyles struct {
 	Accepted    lipgloss.Style
 	Declined    lipgloss.Style
 	NeedsAction lipgloss.Style
+	Tentative   lipgloss.Style
+	Proposed    lipgloss.Style
 	Conflict    lipgloss.Style
 }
 
@@ -43,21 +45,26 @@ func (d *eventsListDelegate) Render(w io.Writer, m list.Model, index int, item l
 	ev := item.(*eventItem)
 
 	mark := "●"
-	switch ev.attendeeStatus {
+	switch ev.AttendeeStatus {
 	case "accepted":
 		mark = s.Accepted.Render("✓")
 	case "declined":
 		mark = s.Declined.Render("✖")
-	case "needsAction", "tentative":
+	case "tentative":
+		mark = s.Tentative.Render("◐")
+	case "needsAction":
 		mark = s.NeedsAction.Render("●")
 	}
+	if _, _, proposed := ev.ProposedTime(); proposed {
+		mark = s.Proposed.Render("⇄")
+	}
 
-	timeRange := ev.start.Format("15:04") + "-" + ev.end.Format("15:04")
+	timeRange := ev.Start.Format("15:04") + "-" + ev.End.Format("15:04")
 
 	conflicts := ""
-	conflictNames := make([]string, len(ev.conflictsWith))
-	for i := range ev.conflictsWith {
-		conflictNames[i] = ev.conflictsWith[i].Summary
+	conflictNames := make([]string, len(ev.ConflictsWith))
+	for i := range ev.ConflictsWith {
+		conflictNames[i] = ev.ConflictsWith[i].Summary
 	}
 	if len(conflictNames) > 0 {
 		conflicts = "!"
@@ -105,14 +112,14 @@ func (d *eventsListDelegate) Render(w io.Writer, m list.Model, index int, item l
 			title = s.NormalTitle.Render(mark + " " + title)
 		} else {
 			isConflicting := false
-			for _, c := range selectedEvent.conflictsWith {
+			for _, c := range selectedEvent.ConflictsWith {
 				if ev.Id == c.Id {
 					isConflicting = true
 					break
 				}
 			}
 			// title = s.NormalTitle.Render(mark + " " + s.NormalTitle.Copy().Border(lipgloss.Border{}).Padding(0).Render(ev.Summary))
-			if ev.attendeeStatus == "declined" {
+			if ev.AttendeeStatus == "declined" {
 				title = s.NormalTitle.Render(mark + " " + s.Declined.Inline(true).Render(ev.Summary))
 			} else {
 				title = s.NormalTitle.Render(mark + " " + s.NormalTitle.Inline(true).Render(ev.Summary))
@@ -127,7 +134,12 @@ func (d *eventsListDelegate) Render(w io.Writer, m list.Model, index int, item l
 		}
 	}
 
-	fmt.Fprintf(w, "%s\n%s", title, desc)
+	calTag := ""
+	if ev.CalendarColor != "" {
+		calTag = lipgloss.NewStyle().Foreground(lipgloss.Color(ev.CalendarColor)).Render("▎")
+	}
+
+	fmt.Fprintf(w, "%s%s\n%s", calTag, title, desc)
 }
 
 // Spacing implements list.ItemDelegate
@@ -145,7 +157,7 @@ func (*eventsListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, appKeys.nextEvent):
+		case key.Matches(msg, appKeys.nextInteresting):
 			// focus next interesting event
 			items := m.Items()
 			events := make([]*eventItem, len(items))
@@ -156,7 +168,8 @@ func (*eventsListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 			i := m.Index()
 			for j := i + 1; j < i+len(events); j++ {
 				ev := events[j%len(events)]
-				if ev.attendeeStatus == "needsAction" || ev.attendeeStatus == "tentative" || len(ev.conflictsWith) > 0 {
+				_, _, proposed := ev.ProposedTime()
+				if ev.AttendeeStatus == "needsAction" || ev.AttendeeStatus == "tentative" || len(ev.ConflictsWith) > 0 || proposed {
 					m.Select(j % len(events))
 					break
 				}
@@ -175,6 +188,15 @@ func (*eventsListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 				updateEventStatus(ev, "declined"),
 			)
 
+		case key.Matches(msg, appKeys.tentativeEvent):
+			return tea.Batch(
+				m.StartSpinner(),
+				updateEventStatus(ev, "tentative"),
+			)
+
+		case key.Matches(msg, appKeys.proposeNewTime):
+			return proposeNewTimeForEvent(ev.Id)
+
 		case key.Matches(msg, appKeys.openInBrowser):
 			open.Start(ev.HtmlLink)
 			return m.NewStatusMessage("open " + ev.Summary + " in browser")
@@ -9,12 +9,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
 
-	"github.com/motemen/go-nuts/oauth2util"
-	"golang.org/x/oauth2/google"
-
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -23,7 +21,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	calendar "google.golang.org/api/calendar/v3"
-	"google.golang.org/api/option"
 )
 
 // printEventsWithTemplate: Output events for the specified date using a template
@@ -59,37 +56,69 @@ const programName = "gcal-tui"
 var day = 24 * time.Hour
 
 type appKeyMap struct {
-	nextEvent     key.Binding
-	acceptEvent   key.Binding
-	declineEvent  key.Binding
-	openInBrowser key.Binding
-	gotoToday     key.Binding
-	reload        key.Binding
-	nextDay       key.Binding
-	prevDay       key.Binding
-	jumpToDay     key.Binding
-	addNote       key.Binding
+	nextInteresting key.Binding
+	switchPane      key.Binding
+	acceptEvent     key.Binding
+	declineEvent    key.Binding
+	tentativeEvent  key.Binding
+	proposeNewTime  key.Binding
+	openInBrowser   key.Binding
+	gotoToday       key.Binding
+	reload          key.Binding
+	nextDay         key.Binding
+	prevDay         key.Binding
+	nextWeek        key.Binding
+	prevWeek        key.Binding
+	jumpToDay       key.Binding
+	toggleView      key.Binding
+	findTime        key.Binding
+	dismissAlarms   key.Binding
+	snoozeAlarms    key.Binding
+	toggleCalendar  key.Binding
+	addNote         key.Binding
+	createTask      key.Binding
+	editTask        key.Binding
+	completeTask    key.Binding
+	deleteTask      key.Binding
 }
 
 func (a appKeyMap) helpKeys() []key.Binding {
 	return []key.Binding{
-		a.nextEvent,
+		a.switchPane,
+		a.nextInteresting,
 		a.acceptEvent,
 		a.declineEvent,
+		a.tentativeEvent,
+		a.proposeNewTime,
 		a.openInBrowser,
 		a.gotoToday,
 		a.reload,
 		a.nextDay,
 		a.prevDay,
+		a.nextWeek,
+		a.prevWeek,
 		a.jumpToDay,
+		a.toggleView,
+		a.findTime,
+		a.dismissAlarms,
+		a.snoozeAlarms,
+		a.toggleCalendar,
 		a.addNote,
+		a.createTask,
+		a.editTask,
+		a.completeTask,
+		a.deleteTask,
 	}
 }
 
 var appKeys = &appKeyMap{
-	nextEvent: key.NewBinding(
+	switchPane: key.NewBinding(
 		key.WithKeys("tab"),
-		key.WithHelp("tab", "next todo"),
+		key.WithHelp("tab", "switch pane"),
+	),
+	nextInteresting: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next interesting"),
 	),
 	acceptEvent: key.NewBinding(
 		key.WithKeys("A"),
@@ -99,6 +128,14 @@ var appKeys = &appKeyMap{
 		key.WithKeys("D"),
 		key.WithHelp("D", "decline event"),
 	),
+	tentativeEvent: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "tentative"),
+	),
+	proposeNewTime: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "propose new time"),
+	),
 	openInBrowser: key.NewBinding(
 		key.WithKeys("o"),
 		key.WithHelp("o", "open in browser"),
@@ -119,22 +156,73 @@ var appKeys = &appKeyMap{
 		key.WithKeys("left"),
 		key.WithHelp("←", "prev day"),
 	),
+	nextWeek: key.NewBinding(
+		key.WithKeys("shift+right"),
+		key.WithHelp("shift+→", "next week"),
+	),
+	prevWeek: key.NewBinding(
+		key.WithKeys("shift+left"),
+		key.WithHelp("shift+←", "prev week"),
+	),
 	jumpToDay: key.NewBinding(
 		key.WithKeys("ctrl+t"),
 		key.WithHelp("ctrl+t", "jump to day"),
 	),
+	toggleView: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "toggle day/week/month view"),
+	),
+	findTime: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", ":findtime"),
+	),
+	dismissAlarms: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "dismiss alarms"),
+	),
+	snoozeAlarms: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "snooze alarms 5m"),
+	),
+	toggleCalendar: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "toggle calendar visibility"),
+	),
 	addNote: key.NewBinding(
 		key.WithKeys("n"),
 		key.WithHelp("n", "add note"),
 	),
+	createTask: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "new task"),
+	),
+	editTask: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit task"),
+	),
+	completeTask: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "complete task"),
+	),
+	deleteTask: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "delete task"),
+	),
 }
 
 type model struct {
 	date   time.Time
 	events []*eventItem
+	todos  []*todoItem
 
 	// Default view
 	eventsList list.Model
+	tasksList  list.Model
+	activePane pane
+
+	// Week/month view
+	viewMode   viewMode
+	weekEvents map[time.Time][]*eventItem
 
 	// Day view
 	inputDate textinput.Model
@@ -143,17 +231,55 @@ type model struct {
 	inputNote       textinput.Model
 	selectedEventId string
 
+	// Propose new time input: HH:MM-HH:MM for the event named by
+	// proposingEventId
+	inputProposeTime textinput.Model
+	proposingEventId string
+
+	// Task input
+	inputTask     textinput.Model
+	editingTaskID string
+
+	// :findtime flow: attendees+duration input -> ranked results -> create
+	// event summary input
+	inputFindTime     textinput.Model
+	findTimeList      list.Model
+	findTimeAttendees []string
+	inputEventSummary textinput.Model
+	selectedSlot      timeSlot
+
 	uiMode uiMode
 
 	errorMessage string
+
+	// Alarms: events whose reminder has fired and not yet scrolled past.
+	firedAlarms []*eventItem
+
+	// Multi-calendar aggregation: calendar IDs hidden from the list via
+	// toggleCalendarVisibility, persisted in activeUIConfig.
+	hiddenCalendars map[string]bool
 }
 
+// pane selects which of the two side-by-side lists (events/tasks) receives
+// key input in uiModeDefault.
+type pane int
+
+const (
+	paneEvents pane = iota
+	paneTasks
+)
+
 type uiMode int
 
 const (
 	uiModeDefault uiMode = iota
 	uiModeInputDate
 	uiModeInputNote
+	uiModeProposeTime
+	uiModeInputTask
+	uiModeFindTimeInput
+	uiModeFindTimeResults
+	uiModeCreateEvent
 )
 
 var thinDotSpinner = spinner.Spinner{
@@ -167,6 +293,8 @@ var styles = eventsListStyles{
 	Accepted:    lipgloss.NewStyle().Foreground(lipgloss.Color("#2EAD71")),
 	Declined:    lipgloss.NewStyle().Strikethrough(true).Foreground(lipgloss.Color("#777777")),
 	NeedsAction: lipgloss.NewStyle().Foreground(lipgloss.Color("#D6CF69")),
+	Tentative:   lipgloss.NewStyle().Foreground(lipgloss.Color("#5AA9E6")),
+	Proposed:    lipgloss.NewStyle().Foreground(lipgloss.Color("#C77DFF")),
 	Conflict:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AD3252")),
 }
 
@@ -208,22 +336,130 @@ func initModelWithDate(date time.Time) model {
 	noteInput.CharLimit = 500
 	noteInput.Width = 50
 
+	proposeTimeInput := textinput.New()
+	proposeTimeInput.Placeholder = "HH:MM-HH:MM"
+	proposeTimeInput.CharLimit = 11
+	proposeTimeInput.Width = 15
+
+	taskInput := textinput.New()
+	taskInput.Placeholder = "Task name..."
+	taskInput.CharLimit = 200
+	taskInput.Width = 50
+
+	tasksList := list.New(nil, &tasksListDelegate{}, 0, 0)
+	tasksList.Title = "Tasks"
+	tasksList.Styles.Title.Background(lipgloss.Color("#8775A8"))
+	tasksList.SetShowStatusBar(false)
+	tasksList.AdditionalShortHelpKeys = appKeys.helpKeys
+	tasksList.AdditionalFullHelpKeys = appKeys.helpKeys
+	tasksList.KeyMap.Filter.Unbind()
+
+	findTimeInput := textinput.New()
+	findTimeInput.Placeholder = "alice@example.com,bob@example.com 30m"
+	findTimeInput.CharLimit = 500
+	findTimeInput.Width = 60
+
+	findTimeList := list.New(nil, &findTimeListDelegate{}, 0, 0)
+	findTimeList.Title = "Candidate slots"
+	findTimeList.Styles.Title.Background(lipgloss.Color("#8775A8"))
+	findTimeList.SetShowStatusBar(false)
+	findTimeList.KeyMap.Filter.Unbind()
+
+	eventSummaryInput := textinput.New()
+	eventSummaryInput.Placeholder = "Event title..."
+	eventSummaryInput.CharLimit = 200
+	eventSummaryInput.Width = 50
+
 	return model{
-		date:       date,
-		eventsList: eventsList,
-		inputDate:  t,
-		inputNote:  noteInput,
+		date:              date,
+		eventsList:        eventsList,
+		tasksList:         tasksList,
+		inputDate:         t,
+		inputNote:         noteInput,
+		inputProposeTime:  proposeTimeInput,
+		inputTask:         taskInput,
+		inputFindTime:     findTimeInput,
+		findTimeList:      findTimeList,
+		inputEventSummary: eventSummaryInput,
+		hiddenCalendars:   activeUIConfig.hiddenSet(),
+	}
+}
+
+// visibleListItems returns m.events as list.Items, filtered down to those
+// whose CalendarID isn't in m.hiddenCalendars.
+func (m model) visibleListItems() []list.Item {
+	items := make([]list.Item, 0, len(m.events))
+	for _, ev := range m.events {
+		if m.hiddenCalendars[ev.CalendarID] {
+			continue
+		}
+		items = append(items, ev)
+	}
+	return items
+}
+
+// toggleSelectedCalendarVisibility hides (or re-shows) the calendar the
+// currently selected event belongs to, persists the choice to
+// uiConfigPath, and re-renders the list without a refetch.
+func (m model) toggleSelectedCalendarVisibility() (model, tea.Cmd) {
+	ev, ok := m.eventsList.SelectedItem().(*eventItem)
+	if !ok || ev.CalendarID == "" {
+		return m, nil
+	}
+
+	if m.hiddenCalendars == nil {
+		m.hiddenCalendars = map[string]bool{}
+	}
+	m.hiddenCalendars[ev.CalendarID] = !m.hiddenCalendars[ev.CalendarID]
+
+	var hidden []string
+	for id, isHidden := range m.hiddenCalendars {
+		if isHidden {
+			hidden = append(hidden, id)
+		}
+	}
+	activeUIConfig.HiddenCalendars = hidden
+	if err := activeUIConfig.save(uiConfigPath); err != nil {
+		m.errorMessage = "failed to save calendar visibility: " + err.Error()
 	}
+
+	return m, m.eventsList.SetItems(m.visibleListItems())
 }
 
 func (m model) reloadEvents(date time.Time) (model, tea.Cmd) {
 	m.date = date
 	m.eventsList.Title = m.date.Format("2006-01-02")
-	return m, tea.Batch(
+	cmds := []tea.Cmd{
 		m.eventsList.StartSpinner(),
 		m.eventsList.SetItems(nil),
+		m.loadEventsFromCache,
 		m.loadEvents,
-	)
+		m.loadTasks,
+	}
+	if m.viewMode != viewModeDay {
+		cmds = append(cmds, m.loadRangeEvents())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// toggleViewMode cycles day -> week -> month -> day, (re)loading whichever
+// range the new mode needs; the single-day list keeps showing m.date's
+// events underneath in all three modes, so switching back to day view is
+// always instant.
+func (m model) toggleViewMode() (model, tea.Cmd) {
+	switch m.viewMode {
+	case viewModeDay:
+		m.viewMode = viewModeWeek
+	case viewModeWeek:
+		m.viewMode = viewModeMonth
+	default:
+		m.viewMode = viewModeDay
+	}
+
+	if m.viewMode == viewModeDay {
+		return m, nil
+	}
+	return m, m.loadRangeEvents()
 }
 
 func (m model) enterJumpToDayMode() (model, tea.Cmd) {
@@ -237,7 +473,7 @@ func (m model) enterNoteInputMode(eventId string) (model, tea.Cmd) {
 	m.errorMessage = ""
 	m.uiMode = uiModeInputNote
 	m.selectedEventId = eventId
-	
+
 	// Find existing note for this event
 	existingNote := ""
 	for _, event := range m.events {
@@ -251,16 +487,71 @@ func (m model) enterNoteInputMode(eventId string) (model, tea.Cmd) {
 			break
 		}
 	}
-	
+
 	m.inputNote.SetValue(existingNote)
 	m.inputNote.Focus()
 	return m, textinput.Blink
 }
 
+// enterProposeTimeMode opens the propose-new-time form, pre-filled with the
+// event's current HH:MM-HH:MM so adjusting it is a small edit rather than
+// typing a time from scratch.
+func (m model) enterProposeTimeMode(eventId string) (model, tea.Cmd) {
+	m.errorMessage = ""
+	m.uiMode = uiModeProposeTime
+	m.proposingEventId = eventId
+
+	value := ""
+	for _, event := range m.events {
+		if event.Id == eventId {
+			value = event.Start.Format("15:04") + "-" + event.End.Format("15:04")
+			break
+		}
+	}
+
+	m.inputProposeTime.SetValue(value)
+	m.inputProposeTime.Focus()
+	return m, textinput.Blink
+}
+
+// enterTaskInputMode opens the task form. An empty taskID means "create a
+// new task"; otherwise the form is pre-filled for editing.
+func (m model) enterTaskInputMode(taskID string) (model, tea.Cmd) {
+	m.errorMessage = ""
+	m.uiMode = uiModeInputTask
+	m.editingTaskID = taskID
+
+	value := ""
+	for _, t := range m.todos {
+		if t.UID == taskID {
+			value = t.Name
+			break
+		}
+	}
+
+	m.inputTask.SetValue(value)
+	m.inputTask.Focus()
+	return m, textinput.Blink
+}
+
+// enterFindTimeInputMode opens the :findtime attendee/duration form; see
+// findtime_list.go for the rest of the flow.
+func (m model) enterFindTimeInputMode() (model, tea.Cmd) {
+	m.errorMessage = ""
+	m.uiMode = uiModeFindTimeInput
+	m.inputFindTime.SetValue("")
+	m.inputFindTime.Focus()
+	return m, textinput.Blink
+}
+
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		m.eventsList.StartSpinner(),
+		m.loadEventsFromCache,
 		m.loadEvents,
+		m.loadTasks,
+		alarmTick(),
+		watchTick(),
 	)
 }
 
@@ -271,29 +562,96 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case eventsLoadedMsg:
+		if msg.events == nil {
+			// A cache-miss from loadEventsFromCache; nothing to show yet,
+			// wait for the live fetch.
+			break
+		}
+
 		m.events = msg.events
 
-		m.eventsList.StopSpinner()
+		title := m.date.Format("2006-01-02")
+		if msg.stale {
+			title += " (syncing...)"
+		}
+		m.eventsList.Title = title
 
-		listItems := make([]list.Item, len(msg.events))
-		for i, ev := range msg.events {
-			listItems[i] = ev
+		if !msg.stale {
+			m.eventsList.StopSpinner()
+		}
+		if msg.banner != "" {
+			m.errorMessage = msg.banner
 		}
 
-		cmds = append(cmds, m.eventsList.SetItems(listItems))
+		cmds = append(cmds, m.eventsList.SetItems(m.visibleListItems()))
 
 	case eventUpdatedMsg:
 		m.eventsList.StopSpinner()
 
+	case weekEventsLoadedMsg:
+		m.weekEvents = msg.events
+
+	case alarmTickMsg:
+		cmds = append(cmds, m.scanForAlarms(), alarmTick())
+
+	case alarmsFiredMsg:
+		m.firedAlarms = append(m.firedAlarms, msg.alarms...)
+
+	case watchTickMsg:
+		cmds = append(cmds, m.pollChanges(), watchTick())
+
+	case eventsChangedMsg:
+		if msg.fullResync {
+			return m.reloadEvents(m.date)
+		}
+		if len(msg.events) > 0 {
+			model, cmd := m.applyEventChanges(msg.events)
+			cmds = append(cmds, cmd)
+			m = model
+		}
+
+	case freeTimeSlotsMsg:
+		m.uiMode = uiModeFindTimeResults
+		listItems := make([]list.Item, len(msg.slots))
+		for i, s := range msg.slots {
+			listItems[i] = s
+		}
+		cmds = append(cmds, m.findTimeList.SetItems(listItems))
+
+	case eventCreatedMsg:
+		m.uiMode = uiModeDefault
+		m.errorMessage = "created: " + msg.summary
+
 	case addNoteMsg:
 		return m.enterNoteInputMode(msg.eventId)
 
+	case proposeTimeMsg:
+		return m.enterProposeTimeMode(msg.eventId)
+
+	case tasksLoadedMsg:
+		m.todos = msg.todos
+
+		due := filterTodosForDate(msg.todos, m.date)
+		listItems := make([]list.Item, len(due))
+		for i, t := range due {
+			listItems[i] = t
+		}
+		cmds = append(cmds, m.tasksList.SetItems(listItems))
+
+	case taskUpdatedMsg:
+		cmds = append(cmds, m.loadTasks)
+
+	case addTaskMsg:
+		return m.enterTaskInputMode(msg.taskID)
+
 	case nonFatalErrorMsg:
 		m.errorMessage = msg.errorMessage
 
 	case tea.WindowSizeMsg:
 		h, v := appStyle.GetFrameSize()
-		m.eventsList.SetSize(msg.Width-h, msg.Height-v)
+		paneWidth := (msg.Width - h) / 2
+		m.eventsList.SetSize(paneWidth, msg.Height-v)
+		m.tasksList.SetSize(paneWidth, msg.Height-v)
 
 	case tea.KeyMsg:
 		switch m.uiMode {
@@ -313,7 +671,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m.reloadEvents(date)
 				}
 			}
-			
+
 		case uiModeInputNote:
 			if msg.String() == "enter" {
 				note := m.inputNote.Value()
@@ -323,6 +681,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.updateEventNote(m.selectedEventId, note),
 				)
 			}
+
+		case uiModeProposeTime:
+			if msg.String() == "enter" {
+				return m.submitProposeTime()
+			}
+
+		case uiModeInputTask:
+			if msg.String() == "enter" {
+				m.uiMode = uiModeDefault
+				return m, m.saveTask(m.editingTaskID, m.inputTask.Value())
+			}
+
+		case uiModeFindTimeInput:
+			if msg.String() == "enter" {
+				return m.submitFindTimeInput()
+			}
+
+		case uiModeFindTimeResults:
+			if msg.String() == "enter" {
+				return m.enterCreateEventMode()
+			}
+
+		case uiModeCreateEvent:
+			if msg.String() == "enter" {
+				m.uiMode = uiModeDefault
+				return m, m.createEventForSelectedSlot(m.inputEventSummary.Value())
+			}
 		}
 	}
 
@@ -330,17 +715,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch m.uiMode {
 	case uiModeDefault:
-		// m.eventsList.StartSpinner()
-		m.eventsList, cmd = m.eventsList.Update(msg)
+		switch m.activePane {
+		case paneEvents:
+			m.eventsList, cmd = m.eventsList.Update(msg)
+		case paneTasks:
+			m.tasksList, cmd = m.tasksList.Update(msg)
+		}
 		cmds = append(cmds, cmd)
 
 	case uiModeInputDate:
 		m.inputDate, cmd = m.inputDate.Update(msg)
 		cmds = append(cmds, cmd)
-		
+
 	case uiModeInputNote:
 		m.inputNote, cmd = m.inputNote.Update(msg)
 		cmds = append(cmds, cmd)
+
+	case uiModeProposeTime:
+		m.inputProposeTime, cmd = m.inputProposeTime.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case uiModeInputTask:
+		m.inputTask, cmd = m.inputTask.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case uiModeFindTimeInput:
+		m.inputFindTime, cmd = m.inputFindTime.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case uiModeFindTimeResults:
+		m.findTimeList, cmd = m.findTimeList.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case uiModeCreateEvent:
+		m.inputEventSummary, cmd = m.inputEventSummary.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -360,13 +769,49 @@ func (m model) handleDefaultModeKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.reloadEvents(m.date)
 
 	case key.Matches(msg, appKeys.nextDay):
-		return m.reloadEvents(m.date.Add(+1 * day))
+		return m.reloadEvents(nextWeekday(m.date))
 
 	case key.Matches(msg, appKeys.prevDay):
-		return m.reloadEvents(m.date.Add(-1 * day))
+		return m.reloadEvents(prevWeekday(m.date))
+
+	case key.Matches(msg, appKeys.nextWeek):
+		return m.reloadEvents(m.date.Add(7 * day))
+
+	case key.Matches(msg, appKeys.prevWeek):
+		return m.reloadEvents(m.date.Add(-7 * day))
 
 	case key.Matches(msg, appKeys.jumpToDay):
 		return m.enterJumpToDayMode()
+
+	case key.Matches(msg, appKeys.toggleView):
+		return m.toggleViewMode()
+
+	case key.Matches(msg, appKeys.findTime):
+		return m.enterFindTimeInputMode()
+
+	case key.Matches(msg, appKeys.dismissAlarms):
+		m.firedAlarms = nil
+		return m, nil
+
+	case key.Matches(msg, appKeys.snoozeAlarms):
+		snoozeAlarms(m.firedAlarms, time.Now())
+		m.firedAlarms = nil
+		return m, nil
+
+	case key.Matches(msg, appKeys.toggleCalendar):
+		return m.toggleSelectedCalendarVisibility()
+
+	case key.Matches(msg, appKeys.switchPane):
+		if m.activePane == paneEvents {
+			m.activePane = paneTasks
+		} else {
+			m.activePane = paneEvents
+		}
+		return m, nil
+	}
+
+	if m.activePane == paneTasks {
+		return m.handleTasksPaneKeyMsg(msg)
 	}
 
 	return m, nil
@@ -376,16 +821,47 @@ func (m model) View() string {
 	if m.uiMode == uiModeInputDate {
 		return appStyle.Render("Date: " + m.inputDate.View())
 	}
-	
+
 	if m.uiMode == uiModeInputNote {
 		return appStyle.Render("Note: " + m.inputNote.View())
 	}
 
-	return appStyle.Render(m.eventsList.View())
+	if m.uiMode == uiModeProposeTime {
+		return appStyle.Render("Propose new time: " + m.inputProposeTime.View())
+	}
+
+	if m.uiMode == uiModeInputTask {
+		return appStyle.Render("Task: " + m.inputTask.View())
+	}
+
+	if m.uiMode == uiModeFindTimeInput {
+		return appStyle.Render("Attendees (+duration, e.g. 30m): " + m.inputFindTime.View())
+	}
+
+	if m.uiMode == uiModeFindTimeResults {
+		return appStyle.Render(m.findTimeList.View())
+	}
+
+	if m.uiMode == uiModeCreateEvent {
+		return appStyle.Render(fmt.Sprintf("%s - %s\nTitle: %s",
+			m.selectedSlot.Start.Format("Mon 01-02 15:04"), m.selectedSlot.End.Format("15:04"), m.inputEventSummary.View()))
+	}
+
+	if m.viewMode != viewModeDay {
+		return appStyle.Render(m.renderRangeView())
+	}
+
+	lists := lipgloss.JoinHorizontal(lipgloss.Top, m.eventsList.View(), m.tasksList.View())
+	if panel := m.renderAlarmsPanel(); panel != "" {
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, panel, lists))
+	}
+	return appStyle.Render(lists)
 }
 
 type eventsLoadedMsg struct {
 	events []*eventItem
+	stale  bool   // true when events came from the cache, not a live fetch
+	banner string // non-empty surfaces a transient errorMessage banner
 }
 
 type eventUpdatedMsg struct {
@@ -400,44 +876,80 @@ type addNoteMsg struct {
 	eventId string
 }
 
+type proposeTimeMsg struct {
+	eventId string
+}
+
 func updateEventStatus(ev *eventItem, status string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		return patchAttendeeOrQueue(ev.Id, attendeePatch{ResponseStatus: status})
+	}
+}
 
-		client, err := calendar.NewService(ctx, option.WithHTTPClient(oauthClient))
-		if err != nil {
-			log.Fatalf("Unable to retrieve Sheets client: %v", err)
-		}
+// proposeNewTimeForEvent switches the model into uiModeProposeTime for
+// eventId, mirroring addNoteToEvent: the list delegate only has a
+// *list.Model to work with, so it sends this message up rather than
+// mutating the model directly.
+func proposeNewTimeForEvent(eventId string) tea.Cmd {
+	return func() tea.Msg {
+		return proposeTimeMsg{eventId: eventId}
+	}
+}
 
-		for _, a := range ev.Attendees {
-			if a.Self {
-				a.ResponseStatus = status
-				ev.AttendeeStatus = status
-				break
-			}
-		}
+// submitProposeTime parses m.inputProposeTime as "HH:MM-HH:MM" against
+// m.date and issues the patch, or reports the parse error and stays in
+// uiModeProposeTime so the user can fix it.
+func (m model) submitProposeTime() (model, tea.Cmd) {
+	start, end, err := parseProposeTimeInput(m.inputProposeTime.Value(), m.date)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return m, nil
+	}
 
-		rawEv, err := client.Events.Patch("primary", ev.Id, &calendar.Event{
-			Attendees: ev.Attendees,
-		}).Do()
-		if err != nil {
-			log.Fatalf("%#v", err)
-		}
+	m.uiMode = uiModeDefault
+	return m, tea.Batch(
+		m.eventsList.StartSpinner(),
+		proposeNewTimePatch(m.proposingEventId, start, end),
+	)
+}
+
+// parseProposeTimeInput parses "HH:MM-HH:MM" into a start/end pair on day.
+func parseProposeTimeInput(s string, day time.Time) (start, end time.Time, err error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("expected HH:MM-HH:MM, got %q", s)
+	}
+
+	startOfDay := truncateToDay(day)
+
+	startTOD, err := time.Parse("15:04", strings.TrimSpace(parts[0]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time %q: %w", parts[0], err)
+	}
+	endTOD, err := time.Parse("15:04", strings.TrimSpace(parts[1]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time %q: %w", parts[1], err)
+	}
 
-		return eventUpdatedMsg{rawEvent: rawEv}
+	start = time.Date(startOfDay.Year(), startOfDay.Month(), startOfDay.Day(), startTOD.Hour(), startTOD.Minute(), 0, 0, startOfDay.Location())
+	end = time.Date(startOfDay.Year(), startOfDay.Month(), startOfDay.Day(), endTOD.Hour(), endTOD.Minute(), 0, 0, startOfDay.Location())
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end time must be after start time")
 	}
+
+	return start, end, nil
 }
 
-func (m model) updateEventNote(eventId string, note string) tea.Cmd {
+// proposeNewTimePatch issues a patch carrying a proposed new time for
+// eventId; see attendeePatch.ProposedStart for how backends handle it.
+func proposeNewTimePatch(eventId string, start, end time.Time) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		client, err := calendar.NewService(ctx, option.WithHTTPClient(oauthClient))
-		if err != nil {
-			log.Fatalf("Unable to retrieve Calendar client: %v", err)
-		}
+		return patchAttendeeOrQueue(eventId, attendeePatch{ProposedStart: &start, ProposedEnd: &end})
+	}
+}
 
-		// Find the event
+func (m model) updateEventNote(eventId string, note string) tea.Cmd {
+	return func() tea.Msg {
 		var targetEvent *eventItem
 		for _, event := range m.events {
 			if event.Id == eventId {
@@ -450,23 +962,30 @@ func (m model) updateEventNote(eventId string, note string) tea.Cmd {
 			return nonFatalErrorMsg{errorMessage: "Event not found"}
 		}
 
-		// Update the attendee comment
-		for _, a := range targetEvent.Attendees {
-			if a.Self {
-				a.Comment = note
-				break
-			}
-		}
+		return patchAttendeeOrQueue(eventId, attendeePatch{Comment: &note})
+	}
+}
 
-		rawEv, err := client.Events.Patch("primary", eventId, &calendar.Event{
-			Attendees: targetEvent.Attendees,
-		}).Do()
-		if err != nil {
-			return nonFatalErrorMsg{errorMessage: fmt.Sprintf("Failed to update note: %v", err)}
-		}
+// patchAttendeeOrQueue applies patch via the backend, journaling it for
+// later replay instead of failing outright when the error looks like a
+// connectivity problem.
+func patchAttendeeOrQueue(eventID string, patch attendeePatch) tea.Msg {
+	ctx := context.Background()
 
-		return eventUpdatedMsg{rawEvent: rawEv}
+	updated, err := backend.PatchAttendee(ctx, eventID, patch)
+	if err == nil {
+		return eventUpdatedMsg{rawEvent: updated.Event}
 	}
+
+	if !isNetworkError(err) {
+		log.Fatalf("%#v", err)
+	}
+
+	if qerr := eventCacheDB.EnqueueWrite(journalEntry{EventID: eventID, Patch: patch}); qerr != nil {
+		return nonFatalErrorMsg{errorMessage: fmt.Sprintf("offline, and failed to queue the change: %v", qerr)}
+	}
+
+	return nonFatalErrorMsg{errorMessage: "offline: change queued and will sync once connectivity returns"}
 }
 
 func addNoteToEvent(eventId string) tea.Cmd {
@@ -475,76 +994,47 @@ func addNoteToEvent(eventId string) tea.Cmd {
 	}
 }
 
+// loadEventsFromCache is a fast, offline-safe command that returns the
+// cached copy of the day (if any) immediately, so the list renders before
+// the network round trip in loadEvents completes.
+func (m model) loadEventsFromCache() tea.Msg {
+	events, ok := eventCacheDB.Get(backend.CalendarID(), m.date)
+	if !ok {
+		return nil
+	}
+	return eventsLoadedMsg{events: events, stale: true}
+}
+
 func (m model) loadEvents() tea.Msg {
 	ctx := context.Background()
 
-	client, err := calendar.NewService(ctx, option.WithHTTPClient(oauthClient))
+	events, err := backend.List(ctx, m.date, m.date.Add(1*day))
 	if err != nil {
-		log.Fatalf("Unable to retrieve Calendar client: %v", err)
-	}
-
-	eventsListResult, err := client.Events.List("primary").
-		ShowDeleted(false).
-		SingleEvents(true).
-		TimeMin(m.date.Format(time.RFC3339)).
-		TimeMax(m.date.Add(1 * day).Format(time.RFC3339)).
-		OrderBy("startTime").
-		Do()
-	if err != nil {
-		log.Fatalf("%+v", err)
-	}
-
-	events := make([]*eventItem, 0, len(eventsListResult.Items))
-	for _, it := range eventsListResult.Items {
-		if it.Start.DateTime == "" || it.End.DateTime == "" {
-			continue
-		}
-
-		event := eventItem{Event: it}
-		event.Start, err = time.Parse(time.RFC3339, it.Start.DateTime)
-		if err != nil {
-			log.Fatalf("%s: %v", it.Summary, err)
-		}
-		event.End, err = time.Parse(time.RFC3339, it.End.DateTime)
-		if err != nil {
-			log.Fatalf("%s: %v", it.Summary, err)
+		if !isNetworkError(err) {
+			log.Fatalf("%+v", err)
 		}
 
-		event.AttendeeStatus = "unknown"
-		for _, a := range it.Attendees {
-			if a.Self {
-				event.AttendeeStatus = a.ResponseStatus
-				break
-			}
+		cached, ok := eventCacheDB.Get(backend.CalendarID(), m.date)
+		if !ok {
+			return nonFatalErrorMsg{errorMessage: fmt.Sprintf("offline and nothing cached for %s: %v", m.date.Format("2006-01-02"), err)}
 		}
-		if event.AttendeeStatus == "unknown" && it.Creator.Self {
-			event.AttendeeStatus = "accepted"
+		return eventsLoadedMsg{
+			events: cached,
+			stale:  true,
+			banner: "offline: showing cached events from " + m.date.Format("2006-01-02"),
 		}
-
-		events = append(events, &event)
 	}
 
-	for _, ev := range events {
-		if ev.Declined() {
-			continue
-		}
-		for _, ev2 := range events {
-			if ev2.Declined() {
-				continue
-			}
-			if ev.Id == ev2.Id {
-				continue
-			}
-
-			if ev.intersectsWith(ev2) {
-				ev.ConflictsWith = append(ev.ConflictsWith, ev2)
-			}
-		}
+	if err := eventCacheDB.Put(backend.CalendarID(), m.date, events); err != nil {
+		log.Printf("failed to update event cache: %v", err)
 	}
 
-	return eventsLoadedMsg{
-		events: events,
-	}
+	_ = eventCacheDB.ReplayJournal(func(entry journalEntry) error {
+		_, err := backend.PatchAttendee(ctx, entry.EventID, entry.Patch)
+		return err
+	})
+
+	return eventsLoadedMsg{events: events}
 }
 
 type eventItem struct {
@@ -553,6 +1043,14 @@ type eventItem struct {
 	End            time.Time
 	AttendeeStatus string
 	ConflictsWith  []*eventItem
+	AlarmOffsets   []time.Duration // how long before Start each alarm should fire
+
+	// CalendarID is the calendar this event was fetched from, and
+	// CalendarColor its backend-reported color (a "#rrggbb" string, or ""
+	// when the backend has no color concept), set when aggregating events
+	// from more than just the account's primary calendar.
+	CalendarID    string
+	CalendarColor string
 }
 
 func (e *eventItem) FilterValue() string {
@@ -571,6 +1069,20 @@ func (e *eventItem) Declined() bool {
 	return e.AttendeeStatus == "declined"
 }
 
+// ProposedTime reports the new time proposed for the event via
+// proposeNewTime, if any. It's read off the current user's attendee
+// comment rather than a dedicated field; see proposedTimeCommentPrefix in
+// google.go for why.
+func (e *eventItem) ProposedTime() (start, end time.Time, ok bool) {
+	for _, a := range e.Attendees {
+		if !a.Self {
+			continue
+		}
+		return parseProposedTimeComment(a.Comment)
+	}
+	return time.Time{}, time.Time{}, false
+}
+
 func (e *eventItem) String() string {
 	return fmt.Sprintf("%s-%s %s",
 		e.Start.Format("15:04"),
@@ -581,6 +1093,17 @@ func (e *eventItem) String() string {
 
 var oauthClient *http.Client
 
+// eventCacheDB backs loadEvents/loadEventsFromCache; it's opened in main()
+// before the TUI starts.
+var eventCacheDB *eventCache
+
+// activeUIConfig and uiConfigPath back toggleSelectedCalendarVisibility;
+// both are set once in main() alongside backend/eventCacheDB.
+var (
+	activeUIConfig uiConfig
+	uiConfigPath   string
+)
+
 func parseDateArg(arg string, base time.Time) (time.Time, error) {
 	if arg == "" {
 		return base, nil
@@ -623,29 +1146,78 @@ func main() {
 	credentialsFile := filepath.Join(confDir, programName, "credentials.json")
 	formatStr := ""
 	dateStr := ""
+	backendKind := "google"
+	inviteFile := ""
+	inviteResponse := "accepted"
+	inviteEmail := ""
+	inviteSendmail := false
+	inviteSync := false
+	daemon := false
 	flag.StringVar(&credentialsFile, "credentials", credentialsFile, "`path` to credentials.json")
 	flag.StringVar(&formatStr, "format", "", "Go template for event output (non-interactive mode)")
 	flag.StringVar(&dateStr, "date", "", "Date to show (YYYY-mm-dd or +1d/-1d)")
+	flag.StringVar(&backendKind, "backend", backendKind, "calendar backend to use: `google`, `caldav` or `outlook`")
+	flag.StringVar(&inviteFile, "invite", "", "`path` to a METHOD:REQUEST .ics file to reply to (non-interactive mode)")
+	flag.StringVar(&inviteResponse, "response", inviteResponse, "response to send with --invite: accepted, tentative or declined")
+	flag.StringVar(&inviteEmail, "email", "", "self email address to use as the ATTENDEE in --invite replies")
+	flag.BoolVar(&inviteSendmail, "sendmail", false, "pipe the --invite reply to sendmail -t instead of stdout")
+	flag.BoolVar(&inviteSync, "sync", false, "also apply the --invite response to the matching calendar event")
+	flag.BoolVar(&daemon, "daemon", false, "run headless, firing desktop/terminal notifications for upcoming events instead of starting the TUI")
+	flag.DurationVar(&defaultAlarmOffset, "alarm-offset", defaultAlarmOffset, "how long before an event with no reminder override to fire its alarm")
 	flag.Parse()
 
-	b, err := os.ReadFile(credentialsFile)
+	uiConfigPath = configFilePath(confDir)
+	cfg, err := loadUIConfig(uiConfigPath)
 	if err != nil {
-		log.Print(err)
-		log.Fatal("Unable to read credentials file -- check README.md to get started.")
+		log.Fatalf("invalid %s: %v", uiConfigPath, err)
 	}
+	activeUIConfig = cfg
+
+	if inviteFile != "" {
+		if inviteSync {
+			var err error
+			backend, err = newBackend(context.Background(), backendKind, credentialsFile, cfg)
+			if err != nil {
+				log.Fatal(err)
+			}
 
-	oauth2Config, err := google.ConfigFromJSON(b, calendar.CalendarEventsScope)
+			eventCacheDB, err = openEventCache(filepath.Join(cacheDir, "events.db"))
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer eventCacheDB.Close()
+		}
+
+		err := handleInviteMode(inviteReplyOptions{
+			file:           inviteFile,
+			responseStatus: inviteResponse,
+			selfEmail:      inviteEmail,
+			sendmail:       inviteSendmail,
+			sync:           inviteSync,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	backend, err = newBackend(context.Background(), backendKind, credentialsFile, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	oauthClient, err = (&oauth2util.Config{
-		OAuth2Config: oauth2Config,
-		Name:         programName,
-	}).CreateOAuth2Client(context.Background())
+	eventCacheDB, err = openEventCache(filepath.Join(cacheDir, "events.db"))
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer eventCacheDB.Close()
+
+	notifier = newNotifier()
+
+	if daemon {
+		runDaemon()
+		return
+	}
 
 	// Determine the date
 	base := today()
@@ -675,64 +1247,5 @@ func fetchTodayEvents() ([]*eventItem, error) {
 }
 
 func fetchEventsForDate(date time.Time) ([]*eventItem, error) {
-	ctx := context.Background()
-	client, err := calendar.NewService(ctx, option.WithHTTPClient(oauthClient))
-	if err != nil {
-		return nil, err
-	}
-	eventsListResult, err := client.Events.List("primary").
-		ShowDeleted(false).
-		SingleEvents(true).
-		TimeMin(date.Format(time.RFC3339)).
-		TimeMax(date.Add(1 * day).Format(time.RFC3339)).
-		OrderBy("startTime").
-		Do()
-	if err != nil {
-		return nil, err
-	}
-	events := make([]*eventItem, 0, len(eventsListResult.Items))
-	for _, it := range eventsListResult.Items {
-		if it.Start.DateTime == "" || it.End.DateTime == "" {
-			continue
-		}
-		event := eventItem{Event: it}
-		event.Start, err = time.Parse(time.RFC3339, it.Start.DateTime)
-		if err != nil {
-			return nil, err
-		}
-		event.End, err = time.Parse(time.RFC3339, it.End.DateTime)
-		if err != nil {
-			return nil, err
-		}
-		event.AttendeeStatus = "unknown"
-		for _, a := range it.Attendees {
-			if a.Self {
-				event.AttendeeStatus = a.ResponseStatus
-				break
-			}
-		}
-		if event.AttendeeStatus == "unknown" && it.Creator.Self {
-			event.AttendeeStatus = "accepted"
-		}
-		events = append(events, &event)
-	}
-
-	// Detect conflicts
-	for _, ev := range events {
-		if ev.Declined() {
-			continue
-		}
-		for _, ev2 := range events {
-			if ev2.Declined() {
-				continue
-			}
-			if ev.Id == ev2.Id {
-				continue
-			}
-			if ev.intersectsWith(ev2) {
-				ev.ConflictsWith = append(ev.ConflictsWith, ev2)
-			}
-		}
-	}
-	return events, nil
+	return backend.List(context.Background(), date, date.Add(1*day))
 }
@@ -14,119 +14,105 @@ func newDate(year int, month time.Month, day int) time.Time {
 	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
 
+// TestHandleDefaultModeKeyMsg_Navigation covers the day-navigation keys:
+// plain left/right step day-by-day but skip weekends, and shift+left/right
+// jump a full week (7 days), weekends included.
 func TestHandleDefaultModeKeyMsg_Navigation(t *testing.T) {
-	// appKeys is a global variable in the main package and should be accessible.
-
 	tests := []struct {
 		name         string
 		initialDate  time.Time
-		keyMsg       tea.KeyMsg // This will be tea.Key{Type: tea.KeyRight} etc.
+		keyMsg       tea.KeyMsg
 		expectedDate time.Time
-		isShiftTest  bool // Flag to denote if this test *intended* to test Shift behavior
 	}{
-		// --- No Shift ---
+		// --- Plain left/right: day-by-day, skipping weekends ---
 		{
-			name: "Right arrow from Mon",
-			initialDate: newDate(2024, time.March, 4), // Monday
-			keyMsg: tea.KeyMsg{Type: tea.KeyRight},
+			name:         "Right arrow from Mon",
+			initialDate:  newDate(2024, time.March, 4), // Monday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyRight},
 			expectedDate: newDate(2024, time.March, 5), // Tuesday
-			isShiftTest: false,
 		},
 		{
-			name: "Left arrow from Tue",
-			initialDate: newDate(2024, time.March, 5), // Tuesday
-			keyMsg: tea.KeyMsg{Type: tea.KeyLeft},
-			expectedDate: newDate(2024, time.March, 4), // Monday
-			isShiftTest: false,
+			name:         "Right arrow from Fri",
+			initialDate:  newDate(2024, time.March, 8), // Friday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyRight},
+			expectedDate: newDate(2024, time.March, 11), // Monday
+		},
+		{
+			name:         "Right arrow from Sat",
+			initialDate:  newDate(2024, time.March, 9), // Saturday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyRight},
+			expectedDate: newDate(2024, time.March, 11), // Monday
 		},
-		// --- Shift + Right (Simulating as best as possible) ---
-		// These tests will likely exercise the non-Shift path due to tea.KeyMsg limitations,
-		// but they document the *intended* Shift behavior.
-		// Expected dates reflect the SHIFTED logic.
 		{
-			name: "Shift+Right from Fri (intended)",
-			initialDate: newDate(2024, time.March, 8), // Friday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftRight}, 
-			expectedDate: newDate(2024, time.March, 11), // Expected Monday
-			isShiftTest: true, // Mark as a Shift test
+			name:         "Right arrow from Sun",
+			initialDate:  newDate(2024, time.March, 10), // Sunday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyRight},
+			expectedDate: newDate(2024, time.March, 11), // Monday
 		},
 		{
-			name: "Shift+Right from Thu (intended)",
-			initialDate: newDate(2024, time.March, 7), // Thursday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftRight},
-			expectedDate: newDate(2024, time.March, 8), // Expected Friday
-			isShiftTest: true,
+			name:         "Left arrow from Tue",
+			initialDate:  newDate(2024, time.March, 5), // Tuesday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyLeft},
+			expectedDate: newDate(2024, time.March, 4), // Monday
+		},
+		{
+			name:         "Left arrow from Mon",
+			initialDate:  newDate(2024, time.March, 11), // Monday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyLeft},
+			expectedDate: newDate(2024, time.March, 8), // Friday
 		},
 		{
-			name: "Shift+Right from Sat (intended)",
-			initialDate: newDate(2024, time.March, 9), // Saturday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftRight},
-			expectedDate: newDate(2024, time.March, 11), // Expected Monday
-			isShiftTest: true,
+			name:         "Left arrow from Sat",
+			initialDate:  newDate(2024, time.March, 9), // Saturday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyLeft},
+			expectedDate: newDate(2024, time.March, 8), // Friday
 		},
 		{
-			name: "Shift+Right from Sun (intended)",
-			initialDate: newDate(2024, time.March, 10), // Sunday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftRight},
-			expectedDate: newDate(2024, time.March, 11), // Expected Monday
-			isShiftTest: true,
+			name:         "Left arrow from Sun",
+			initialDate:  newDate(2024, time.March, 10), // Sunday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyLeft},
+			expectedDate: newDate(2024, time.March, 8), // Friday
 		},
-		// --- Shift + Left (Simulating as best as possible) ---
-		// Expected dates reflect the SHIFTED logic.
+		// --- shift+left/right: jump 7 days, weekends included ---
 		{
-			name: "Shift+Left from Mon (intended)",
-			initialDate: newDate(2024, time.March, 11), // Monday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftLeft},
-			expectedDate: newDate(2024, time.March, 8), // Expected Friday
-			isShiftTest: true,
+			name:         "Shift+Right from Fri",
+			initialDate:  newDate(2024, time.March, 8), // Friday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyShiftRight},
+			expectedDate: newDate(2024, time.March, 15), // Friday, one week later
 		},
 		{
-			name: "Shift+Left from Tue (intended)",
-			initialDate: newDate(2024, time.March, 12), // Tuesday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftLeft},
-			expectedDate: newDate(2024, time.March, 11), // Expected Monday
-			isShiftTest: true,
+			name:         "Shift+Right from Thu",
+			initialDate:  newDate(2024, time.March, 7), // Thursday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyShiftRight},
+			expectedDate: newDate(2024, time.March, 14), // Thursday, one week later
 		},
 		{
-			name: "Shift+Left from Sun (intended)",
-			initialDate: newDate(2024, time.March, 10), // Sunday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftLeft},
-			expectedDate: newDate(2024, time.March, 8), // Expected Friday
-			isShiftTest: true,
+			name:         "Shift+Left from Mon",
+			initialDate:  newDate(2024, time.March, 11), // Monday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyShiftLeft},
+			expectedDate: newDate(2024, time.March, 4), // Monday, one week earlier
 		},
 		{
-			name: "Shift+Left from Sat (intended)",
-			initialDate: newDate(2024, time.March, 9), // Saturday
-			keyMsg: tea.KeyMsg{Type: tea.KeyShiftLeft},
-			expectedDate: newDate(2024, time.March, 8), // Expected Friday
-			isShiftTest: true,
+			name:         "Shift+Left from Tue",
+			initialDate:  newDate(2024, time.March, 12), // Tuesday
+			keyMsg:       tea.KeyMsg{Type: tea.KeyShiftLeft},
+			expectedDate: newDate(2024, time.March, 5), // Tuesday, one week earlier
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := initModelWithDate(tt.initialDate)
-			nextModel, _ := m.handleDefaultModeKeyMsg(tt.keyMsg) // tea.KeyMsg directly
+			nextModel, _ := m.handleDefaultModeKeyMsg(tt.keyMsg)
 
-			if concreteNextModel, ok := nextModel.(model); ok {
-				// Due to the KeyMsg limitation, for tests marked isShiftTest,
-				// the actual outcome will be the non-shifted one if key.Matches cannot
-				// distinguish Shift from the provided KeyMsg.
-				// The 'expectedDate' for isShiftTest cases is set to the *shifted* outcome
-				// to highlight this discrepancy if the Shift logic isn't triggered.
-				if !concreteNextModel.date.Equal(tt.expectedDate) {
-					t.Errorf("initial: %s (%s), key: %s (isShiftTest: %t), expected: %s (%s), got: %s (%s)",
-						tt.initialDate.Format("Mon 2006-01-02"),
-						tt.initialDate.Weekday(),
-						tt.name, // Using tt.name for key description as it's more descriptive
-						tt.isShiftTest,
-						tt.expectedDate.Format("Mon 2006-01-02"),
-						tt.expectedDate.Weekday(),
-						concreteNextModel.date.Format("Mon 2006-01-02"),
-						concreteNextModel.date.Weekday())
-				}
-			} else {
-				t.Errorf("nextModel is not of type model")
+			concreteNextModel, ok := nextModel.(model)
+			if !ok {
+				t.Fatalf("nextModel is not of type model")
+			}
+			if !concreteNextModel.date.Equal(tt.expectedDate) {
+				t.Errorf("%s: got %s, want %s", tt.name,
+					concreteNextModel.date.Format("Mon 2006-01-02"),
+					tt.expectedDate.Format("Mon 2006-01-02"))
 			}
 		})
 	}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Notifier dispatches a single alarm to the OS (or the terminal, as a last
+// resort). Implementations should not block for long; alarm.go calls
+// Notify from the same goroutine that drives the bubbletea event loop.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// newNotifier picks a Notifier appropriate for the current platform,
+// falling back to bellNotifier when no desktop notifier is available (e.g.
+// a minimal Linux box with no notify-send, or any unrecognized GOOS).
+func newNotifier() Notifier {
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return notifySendNotifier{}
+		}
+	case "darwin":
+		if _, err := exec.LookPath("terminal-notifier"); err == nil {
+			return terminalNotifierNotifier{}
+		}
+		if _, err := exec.LookPath("osascript"); err == nil {
+			return osascriptNotifier{}
+		}
+	case "windows":
+		if _, err := exec.LookPath("powershell"); err == nil {
+			return windowsToastNotifier{}
+		}
+	}
+	return bellNotifier{}
+}
+
+// notifySendNotifier shells out to notify-send, present on most Linux
+// desktops (GNOME, KDE, ...).
+type notifySendNotifier struct{}
+
+func (notifySendNotifier) Notify(title, body string) error {
+	return exec.Command("notify-send", title, body).Run()
+}
+
+// terminalNotifierNotifier shells out to the terminal-notifier CLI, the
+// common way to post macOS notifications from a script.
+type terminalNotifierNotifier struct{}
+
+func (terminalNotifierNotifier) Notify(title, body string) error {
+	return exec.Command("terminal-notifier", "-title", title, "-message", body).Run()
+}
+
+// osascriptNotifier is the macOS fallback when terminal-notifier isn't
+// installed: osascript ships with every macOS install.
+type osascriptNotifier struct{}
+
+func (osascriptNotifier) Notify(title, body string) error {
+	script := fmt.Sprintf("display notification %q with title %q", body, title)
+	return exec.Command("osascript", "-e", script).Run()
+}
+
+// windowsToastNotifier posts a Windows toast notification via the
+// WinRT notification APIs, invoked through a short PowerShell script.
+type windowsToastNotifier struct{}
+
+func (windowsToastNotifier) Notify(title, body string) error {
+	script := fmt.Sprintf(`
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$texts = $template.GetElementsByTagName("text")
+$texts.Item(0).AppendChild($template.CreateTextNode(%q)) | Out-Null
+$texts.Item(1).AppendChild($template.CreateTextNode(%q)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier(%q).Show($toast)
+`, title, body, programName)
+	return exec.Command("powershell", "-NoProfile", "-Command", script).Run()
+}
+
+// bellNotifier is the plain-terminal fallback: a bell character plus an
+// on-screen line, used when no desktop notifier is available.
+type bellNotifier struct{}
+
+func (bellNotifier) Notify(title, body string) error {
+	fmt.Fprintf(os.Stderr, "\a[%s] %s\n", title, body)
+	return nil
+}
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// graphBaseURL is the Microsoft Graph v1.0 root. There is no Go SDK
+// dependency in this tree, so the outlook backend talks to Graph directly
+// over net/http, the same way caldav.go talks to its server via go-webdav
+// rather than a higher-level client.
+const graphBaseURL = "https://graph.microsoft.com/v1.0"
+
+// outlookBackend implements CalendarBackend against Microsoft Graph /
+// Outlook Calendar, for users without a Google account.
+type outlookBackend struct {
+	client *http.Client
+
+	// calendarIDs is the set of Graph calendar IDs List/PollChanges
+	// aggregate, mirroring googleBackend.calendarIDs; an empty entry ("")
+	// means the account's default calendar, reached via /me/calendarview
+	// rather than /me/calendars/{id}/calendarview. calendarID stays the
+	// first of these and is what PatchAttendee/CalendarID/PollChanges use.
+	calendarID  string
+	calendarIDs []string
+
+	// selfEmail is resolved once via GET /me, best-effort, and used to
+	// include the signed-in user's own schedule in FreeBusy queries (Graph
+	// has no "me" shorthand in getSchedule's schedules list).
+	selfEmail string
+
+	// taskListID is resolved lazily (and cached) the first time a task
+	// operation runs, naming the Microsoft To Do list gcal-tui stores its
+	// tasks in.
+	taskListID string
+}
+
+func newOutlookBackend(ctx context.Context, credentialsFile string, cfg uiConfig) (CalendarBackend, error) {
+	httpClient, err := getOutlookOAuthClient(credentialsFile, []string{
+		"offline_access",
+		"Calendars.ReadWrite",
+		"Tasks.ReadWrite",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calendarIDs := cfg.Calendars
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{""}
+	}
+
+	return &outlookBackend{
+		client:      httpClient,
+		calendarID:  calendarIDs[0],
+		calendarIDs: calendarIDs,
+		selfEmail:   fetchSelfEmail(ctx, httpClient),
+	}, nil
+}
+
+func (o *outlookBackend) CalendarID() string {
+	if o.calendarID == "" {
+		return "primary"
+	}
+	return o.calendarID
+}
+
+// List fetches each of o.calendarIDs' calendarView in [start, end) and
+// merges the results sorted by start time, tagging every event with the
+// calendar it came from, the same shape googleBackend.List and
+// caldavBackend.List produce. CalendarColor is left empty: Graph calendars
+// do have a color property, but it's one of a small fixed palette of names
+// (e.g. "lightBlue") rather than an "#rrggbb" string, so it isn't rendered
+// as a lipgloss color without a lookup table this backend doesn't have yet.
+func (o *outlookBackend) List(ctx context.Context, start, end time.Time) ([]*eventItem, error) {
+	var events []*eventItem
+
+	for _, calendarID := range o.calendarIDs {
+		page, err := o.listCalendarView(ctx, calendarID, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ge := range page {
+			ev, err := eventFromGraphEvent(ge)
+			if err != nil {
+				return nil, err
+			}
+			ev.CalendarID = calendarID
+			if ev.CalendarID == "" {
+				ev.CalendarID = "primary"
+			}
+			events = append(events, ev)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	markConflicts(events)
+
+	return events, nil
+}
+
+// listCalendarView pages through /calendarview for a single calendar,
+// following @odata.nextLink until exhausted.
+func (o *outlookBackend) listCalendarView(ctx context.Context, calendarID string, start, end time.Time) ([]graphEvent, error) {
+	q := url.Values{}
+	q.Set("startDateTime", start.UTC().Format(graphDateTimeLayout))
+	q.Set("endDateTime", end.UTC().Format(graphDateTimeLayout))
+	q.Set("$orderby", "start/dateTime")
+
+	nextURL := o.calendarPath(calendarID, "calendarview") + "?" + q.Encode()
+
+	var events []graphEvent
+	for nextURL != "" {
+		respBody, err := graphRequest(ctx, o.client, http.MethodGet, nextURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page graphEventsPage
+		if err := json.Unmarshal(respBody, &page); err != nil {
+			return nil, err
+		}
+
+		events = append(events, page.Value...)
+		nextURL = page.NextLink
+	}
+
+	return events, nil
+}
+
+// calendarPath builds the Graph URL for a calendar sub-resource (e.g.
+// "calendarview", "events"): an empty calendarID addresses the default
+// calendar via /me/{resource}, anything else via /me/calendars/{id}/{resource}.
+func (o *outlookBackend) calendarPath(calendarID, resource string) string {
+	if calendarID == "" {
+		return graphBaseURL + "/me/" + resource
+	}
+	return graphBaseURL + "/me/calendars/" + url.PathEscape(calendarID) + "/" + resource
+}
+
+func (o *outlookBackend) PatchAttendee(ctx context.Context, id string, patch attendeePatch) (*eventItem, error) {
+	if patch.ProposedStart != nil && patch.ProposedEnd != nil {
+		return nil, fmt.Errorf("proposing a new time is not supported by the outlook backend")
+	}
+
+	// An empty ResponseStatus must leave the response status untouched (see
+	// attendeePatch), so a comment-only patch can't go through one of
+	// Graph's accept/decline/tentativelyAccept actions: calling one of those
+	// always sets a response, and resolving one from the event's current
+	// status just to satisfy the endpoint would re-notify the organizer over
+	// a simple note edit. Graph has no PATCH field that attaches a comment
+	// to an attendee independently of responding, so there's nothing honest
+	// to do with patch.Comment here.
+	if patch.ResponseStatus == "" {
+		if patch.Comment != nil {
+			return nil, fmt.Errorf("commenting without responding is not supported by the outlook backend")
+		}
+		ge, err := o.getEvent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return eventFromGraphEvent(*ge)
+	}
+
+	action, err := graphRespondAction(patch.ResponseStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"sendResponse": true}
+	if patch.Comment != nil {
+		body["comment"] = *patch.Comment
+	}
+
+	actionURL := fmt.Sprintf("%s/me/events/%s/%s", graphBaseURL, url.PathEscape(id), action)
+	if _, err := graphRequest(ctx, o.client, http.MethodPost, actionURL, body); err != nil {
+		return nil, err
+	}
+
+	ge, err := o.getEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return eventFromGraphEvent(*ge)
+}
+
+// graphRespondAction maps a Google-style responseStatus to the Graph event
+// action that produces it: Graph has no generic "set my response" PATCH,
+// only accept/decline/tentativelyAccept endpoints.
+func graphRespondAction(status string) (string, error) {
+	switch status {
+	case "accepted":
+		return "accept", nil
+	case "declined":
+		return "decline", nil
+	case "tentative":
+		return "tentativelyAccept", nil
+	default:
+		return "", fmt.Errorf("unsupported response status %q for the outlook backend", status)
+	}
+}
+
+func (o *outlookBackend) getEvent(ctx context.Context, id string) (*graphEvent, error) {
+	respBody, err := graphRequest(ctx, o.client, http.MethodGet, fmt.Sprintf("%s/me/events/%s", graphBaseURL, url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ge graphEvent
+	if err := json.Unmarshal(respBody, &ge); err != nil {
+		return nil, err
+	}
+	return &ge, nil
+}
+
+func (o *outlookBackend) CreateEvent(ctx context.Context, draft eventDraft) (*eventItem, error) {
+	body := map[string]interface{}{
+		"subject": draft.Summary,
+		"start":   graphDateTime{DateTime: draft.Start.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"},
+		"end":     graphDateTime{DateTime: draft.End.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"},
+	}
+
+	var attendees []map[string]interface{}
+	for _, email := range draft.Attendees {
+		attendees = append(attendees, map[string]interface{}{
+			"emailAddress": map[string]string{"address": email},
+			"type":         "required",
+		})
+	}
+	if len(attendees) > 0 {
+		body["attendees"] = attendees
+	}
+	if draft.ConferenceData {
+		body["isOnlineMeeting"] = true
+		body["onlineMeetingProvider"] = "teamsForBusiness"
+	}
+
+	respBody, err := graphRequest(ctx, o.client, http.MethodPost, o.calendarPath(o.calendarID, "events"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ge graphEvent
+	if err := json.Unmarshal(respBody, &ge); err != nil {
+		return nil, err
+	}
+
+	return eventFromGraphEvent(ge)
+}
+
+// FreeBusy calls Graph's getSchedule, which (unlike Google's freebusy
+// query) has no shorthand for "the signed-in user": o.selfEmail is
+// prepended to the schedules list when known.
+func (o *outlookBackend) FreeBusy(ctx context.Context, attendees []string, start, end time.Time) ([]busyInterval, error) {
+	schedules := attendees
+	if o.selfEmail != "" {
+		schedules = append([]string{o.selfEmail}, attendees...)
+	}
+
+	body := map[string]interface{}{
+		"schedules":                schedules,
+		"startTime":                graphDateTime{DateTime: start.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"},
+		"endTime":                  graphDateTime{DateTime: end.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"},
+		"availabilityViewInterval": 30,
+	}
+
+	respBody, err := graphRequest(ctx, o.client, http.MethodPost, graphBaseURL+"/me/calendar/getSchedule", body)
+	if err != nil {
+		return nil, fmt.Errorf("getSchedule: %w", err)
+	}
+
+	var result struct {
+		Value []struct {
+			ScheduleItems []struct {
+				Status string        `json:"status"`
+				Start  graphDateTime `json:"start"`
+				End    graphDateTime `json:"end"`
+			} `json:"scheduleItems"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+
+	var busy []busyInterval
+	for _, schedule := range result.Value {
+		for _, item := range schedule.ScheduleItems {
+			if item.Status == "free" {
+				continue
+			}
+			bstart, err := time.Parse(time.RFC3339Nano, item.Start.DateTime+"Z")
+			if err != nil {
+				continue
+			}
+			bend, err := time.Parse(time.RFC3339Nano, item.End.DateTime+"Z")
+			if err != nil {
+				continue
+			}
+			busy = append(busy, busyInterval{Start: bstart, End: bend})
+		}
+	}
+	return busy, nil
+}
+
+// graphDateTimeLayout is the fractional-second layout Graph accepts/returns
+// for dateTimeTimeZone values once every request carries the
+// Prefer: outlook.timezone="UTC" header (set by graphRequest), which
+// normalizes every dateTime field to UTC and sidesteps parsing Windows
+// timezone names.
+const graphDateTimeLayout = "2006-01-02T15:04:05.0000000"
+
+// graphDateTime is the Graph "dateTimeTimeZone" shape used by start/end on
+// events and schedule items.
+type graphDateTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// graphEvent is the subset of Graph's event resource this backend reads
+// and writes.
+type graphEvent struct {
+	ID             string          `json:"id"`
+	Subject        string          `json:"subject"`
+	WebLink        string          `json:"webLink"`
+	Start          graphDateTime   `json:"start"`
+	End            graphDateTime   `json:"end"`
+	Attendees      []graphAttendee `json:"attendees"`
+	ResponseStatus struct {
+		Response string `json:"response"`
+	} `json:"responseStatus"`
+}
+
+type graphAttendee struct {
+	EmailAddress struct {
+		Address string `json:"address"`
+	} `json:"emailAddress"`
+	Status struct {
+		Response string `json:"response"`
+	} `json:"status"`
+}
+
+type graphEventsPage struct {
+	Value    []graphEvent `json:"value"`
+	NextLink string       `json:"@odata.nextLink"`
+}
+
+// eventFromGraphEvent converts a Graph event into the *eventItem shape
+// used by the rest of the TUI, reusing the Google calendar.Event struct as
+// the common in-memory representation, the same way caldavBackend's
+// eventFromObject does.
+func eventFromGraphEvent(ge graphEvent) (*eventItem, error) {
+	raw := &calendar.Event{
+		Id:       ge.ID,
+		Summary:  ge.Subject,
+		HtmlLink: ge.WebLink,
+		Start:    &calendar.EventDateTime{DateTime: ge.Start.DateTime + "Z"},
+		End:      &calendar.EventDateTime{DateTime: ge.End.DateTime + "Z"},
+	}
+
+	raw.Attendees = append(raw.Attendees, &calendar.EventAttendee{
+		Self:           true,
+		ResponseStatus: attendeeStatusFromGraphResponse(ge.ResponseStatus.Response),
+	})
+	for _, a := range ge.Attendees {
+		if a.EmailAddress.Address == "" {
+			continue
+		}
+		raw.Attendees = append(raw.Attendees, &calendar.EventAttendee{
+			Email:          a.EmailAddress.Address,
+			ResponseStatus: attendeeStatusFromGraphResponse(a.Status.Response),
+		})
+	}
+
+	// time.Parse(time.RFC3339, ...) tolerates Graph's extra fractional
+	// digits even though RFC3339's reference layout has none, so this can
+	// go through the same eventFromRaw the google/caldav backends use.
+	return eventFromRaw(raw)
+}
+
+// attendeeStatusFromGraphResponse maps a Graph responseStatus.response
+// value to the Google-style status eventItem/list.go expect.
+func attendeeStatusFromGraphResponse(response string) string {
+	switch response {
+	case "accepted", "organizer":
+		return "accepted"
+	case "declined":
+		return "declined"
+	case "tentativelyAccepted":
+		return "tentative"
+	default:
+		return "needsAction"
+	}
+}
+
+type graphErrorBody struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// graphHTTPError is returned by graphRequest for any non-2xx response, so
+// callers like PollChanges can tell an expired delta link (410 Gone) apart
+// from a real failure.
+type graphHTTPError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *graphHTTPError) Error() string {
+	return fmt.Sprintf("graph: %d %s", e.StatusCode, e.Message)
+}
+
+func isGraphGone(err error) bool {
+	var herr *graphHTTPError
+	return errors.As(err, &herr) && herr.StatusCode == http.StatusGone
+}
+
+// graphRequest issues a Graph API call and returns its raw JSON body.
+// rawURL may be a path-relative Graph URL built by this backend or an
+// absolute @odata.nextLink/@odata.deltaLink returned by a previous call.
+func graphRequest(ctx context.Context, client *http.Client, method, rawURL string, body interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	// Normalizes every dateTimeTimeZone field in the response to UTC so
+	// this backend never has to resolve a Windows timezone name.
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		msg := resp.Status
+		var ge graphErrorBody
+		if json.Unmarshal(respBody, &ge) == nil && ge.Error.Message != "" {
+			msg = ge.Error.Message
+		}
+		return nil, &graphHTTPError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	return respBody, nil
+}
+
+// fetchSelfEmail resolves the signed-in user's mail address via GET /me,
+// best-effort (an empty result just means FreeBusy won't include the
+// user's own schedule), mirroring how googleBackend.colorByID is populated
+// best-effort at construction.
+func fetchSelfEmail(ctx context.Context, client *http.Client) string {
+	respBody, err := graphRequest(ctx, client, http.MethodGet, graphBaseURL+"/me", nil)
+	if err != nil {
+		return ""
+	}
+
+	var user struct {
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	}
+	if json.Unmarshal(respBody, &user) != nil {
+		return ""
+	}
+	if user.Mail != "" {
+		return user.Mail
+	}
+	return user.UserPrincipalName
+}
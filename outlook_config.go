@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// outlookConfig holds the Azure AD app registration details needed to talk
+// to Microsoft Graph. ClientID is registered as a public client (no
+// secret) since gcal-tui authorizes via the device code flow rather than
+// hosting a redirect.
+type outlookConfig struct {
+	TenantID string `json:"tenantId"`
+	ClientID string `json:"clientId"`
+}
+
+// loadOutlookConfig reads the tenant/client IDs from credentialsFile, the
+// same JSON-credentials-file convention used by the google and caldav
+// backends.
+func loadOutlookConfig(credentialsFile string) (*outlookConfig, error) {
+	b, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg outlookConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.TenantID == "" || cfg.ClientID == "" {
+		return nil, fmt.Errorf("credentials file must set \"tenantId\" and \"clientId\" for the outlook backend")
+	}
+
+	return &cfg, nil
+}
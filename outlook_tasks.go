@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// graphTodoTask is the subset of Microsoft To Do's task resource this
+// backend reads and writes.
+type graphTodoTask struct {
+	ID   string `json:"id"`
+	Title string `json:"title"`
+	Body  struct {
+		Content string `json:"content"`
+	} `json:"body"`
+	Importance  string         `json:"importance"`
+	Status      string         `json:"status"`
+	DueDateTime *graphDateTime `json:"dueDateTime"`
+}
+
+// ensureTaskList resolves (creating if necessary) the Microsoft To Do list
+// gcal-tui stores its tasks in, named after programName, and caches it on
+// the backend so later calls in the same run skip the lookup.
+func (o *outlookBackend) ensureTaskList(ctx context.Context) (string, error) {
+	if o.taskListID != "" {
+		return o.taskListID, nil
+	}
+
+	respBody, err := graphRequest(ctx, o.client, http.MethodGet, graphBaseURL+"/me/todo/lists", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var lists struct {
+		Value []struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"displayName"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &lists); err != nil {
+		return "", err
+	}
+	for _, l := range lists.Value {
+		if l.DisplayName == programName {
+			o.taskListID = l.ID
+			return o.taskListID, nil
+		}
+	}
+
+	respBody, err = graphRequest(ctx, o.client, http.MethodPost, graphBaseURL+"/me/todo/lists", map[string]string{"displayName": programName})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", err
+	}
+	o.taskListID = created.ID
+	return o.taskListID, nil
+}
+
+func (o *outlookBackend) ListTasks(ctx context.Context) ([]*todoItem, error) {
+	listID, err := o.ensureTaskList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := graphRequest(ctx, o.client, http.MethodGet,
+		fmt.Sprintf("%s/me/todo/lists/%s/tasks", graphBaseURL, url.PathEscape(listID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks struct {
+		Value []graphTodoTask `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &tasks); err != nil {
+		return nil, err
+	}
+
+	out := make([]*todoItem, 0, len(tasks.Value))
+	for _, gt := range tasks.Value {
+		out = append(out, todoFromGraphTask(gt))
+	}
+	return out, nil
+}
+
+func (o *outlookBackend) CreateTask(ctx context.Context, t *todoItem) error {
+	listID, err := o.ensureTaskList(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"title":      t.Name,
+		"importance": importanceFromPriority(t.Priority),
+	}
+	if t.Description != "" {
+		body["body"] = map[string]string{"content": t.Description, "contentType": "text"}
+	}
+	if !t.DueDateTime.IsZero() {
+		body["dueDateTime"] = graphDateTime{DateTime: t.DueDateTime.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"}
+	}
+
+	respBody, err := graphRequest(ctx, o.client, http.MethodPost,
+		fmt.Sprintf("%s/me/todo/lists/%s/tasks", graphBaseURL, url.PathEscape(listID)), body)
+	if err != nil {
+		return err
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return err
+	}
+	t.UID = created.ID
+	return nil
+}
+
+// CompleteTask always marks the Graph task completed for good: todoItem's
+// RRule is never populated by this backend (see todoFromGraphTask), so
+// t.rule() is nil and there's no repeating occurrence to advance to,
+// unlike caldavBackend.CompleteTask.
+func (o *outlookBackend) CompleteTask(ctx context.Context, t *todoItem) error {
+	listID, err := o.ensureTaskList(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.Completed = true
+	_, err = graphRequest(ctx, o.client, http.MethodPatch,
+		fmt.Sprintf("%s/me/todo/lists/%s/tasks/%s", graphBaseURL, url.PathEscape(listID), url.PathEscape(t.UID)),
+		map[string]string{"status": "completed"})
+	return err
+}
+
+func (o *outlookBackend) DeleteTask(ctx context.Context, uid string) error {
+	listID, err := o.ensureTaskList(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = graphRequest(ctx, o.client, http.MethodDelete,
+		fmt.Sprintf("%s/me/todo/lists/%s/tasks/%s", graphBaseURL, url.PathEscape(listID), url.PathEscape(uid)), nil)
+	return err
+}
+
+// todoFromGraphTask converts a Microsoft To Do task into a todoItem. RRule
+// is always left empty: Graph encodes recurrence as its own
+// patternedRecurrence object, not an RFC 5545 RRULE, and Microsoft To Do
+// already auto-advances a repeating task's due date on completion
+// server-side, so todoItem.rule()'s RRule-based occursOn/nextOccurrence
+// logic isn't needed for this backend to behave correctly day-to-day.
+func todoFromGraphTask(gt graphTodoTask) *todoItem {
+	t := &todoItem{
+		UID:         gt.ID,
+		Name:        gt.Title,
+		Description: gt.Body.Content,
+		Priority:    priorityFromImportance(gt.Importance),
+		Completed:   gt.Status == "completed",
+	}
+	if gt.DueDateTime != nil {
+		if due, err := time.Parse(time.RFC3339, gt.DueDateTime.DateTime+"Z"); err == nil {
+			t.DueDateTime = due
+		}
+	}
+	return t
+}
+
+// importanceFromPriority and priorityFromImportance convert between
+// todoItem.Priority's VTODO-style 1(highest)-9(lowest) scale and Graph's
+// three-value importance.
+func importanceFromPriority(p int) string {
+	switch {
+	case p != 0 && p <= 4:
+		return "high"
+	case p >= 6:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+func priorityFromImportance(imp string) int {
+	switch imp {
+	case "high":
+		return 1
+	case "low":
+		return 9
+	default:
+		return 5
+	}
+}
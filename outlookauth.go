@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+// outlookTokenFile is separate from tokenFile (the google backend's), so
+// running with --backend=outlook doesn't clobber the token cached for
+// --backend=google and vice versa.
+var outlookTokenFile = filepath.Join(cacheDir, "outlook-token.json")
+
+// getOutlookOAuthClient authorizes against Azure AD using credentialsFile's
+// tenantId/clientId and returns an *http.Client ready to call Microsoft
+// Graph. Unlike getGoogleOAuthClient, it authorizes via the device code
+// grant: gcal-tui has no redirect URI to host, and device code is the
+// flow Azure AD expects from a public client like a CLI.
+func getOutlookOAuthClient(credentialsFile string, scopes []string) (*http.Client, error) {
+	cfg, err := loadOutlookConfig(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthCfg := &oauth2.Config{
+		ClientID: cfg.ClientID,
+		Endpoint: microsoft.AzureADEndpoint(cfg.TenantID),
+		Scopes:   scopes,
+	}
+
+	token, err := restoreTokenAt(outlookTokenFile)
+	if err != nil {
+		token, err = doDeviceAuthorization(context.Background(), oauthCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := storeTokenAt(outlookTokenFile, token); err != nil {
+			return nil, err
+		}
+	}
+
+	return oauthCfg.Client(context.Background(), token), nil
+}
+
+// doDeviceAuthorization runs the OAuth2 device authorization grant: the
+// user visits a short verification URL on any device and enters the
+// printed code, while this process polls Azure AD for the token.
+func doDeviceAuthorization(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	auth, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("To authorize %s, visit %s and enter code %s\n", programName, auth.VerificationURI, auth.UserCode)
+
+	return config.DeviceAccessToken(ctx, auth)
+}
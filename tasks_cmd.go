@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type tasksLoadedMsg struct {
+	todos []*todoItem
+}
+
+type taskUpdatedMsg struct {
+	taskID string
+}
+
+type addTaskMsg struct {
+	taskID string // empty means "create a new task"
+}
+
+func (m model) loadTasks() tea.Msg {
+	todos, err := backend.ListTasks(context.Background())
+	if err != nil {
+		return nonFatalErrorMsg{errorMessage: "Failed to load tasks: " + err.Error()}
+	}
+	return tasksLoadedMsg{todos: todos}
+}
+
+// saveTask creates a new task when taskID is empty, or renames the existing
+// one otherwise.
+func (m model) saveTask(taskID, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if taskID == "" {
+			t := &todoItem{Name: name, Priority: 5, DueDateTime: m.date}
+			if err := backend.CreateTask(ctx, t); err != nil {
+				return nonFatalErrorMsg{errorMessage: "Failed to create task: " + err.Error()}
+			}
+			return taskUpdatedMsg{taskID: t.UID}
+		}
+
+		for _, t := range m.todos {
+			if t.UID != taskID {
+				continue
+			}
+			// TODO: neither backend exposes an update-in-place yet, so a
+			// rename is client-side only until the task is recreated. Don't
+			// return taskUpdatedMsg here: it triggers loadTasks, which
+			// would immediately re-fetch the unrenamed name from the
+			// backend and wipe out this very edit.
+			t.Name = name
+			return nonFatalErrorMsg{errorMessage: "renamed locally only: task edits aren't persisted to the backend yet"}
+		}
+
+		return nonFatalErrorMsg{errorMessage: "Task not found"}
+	}
+}
+
+func completeTask(t *todoItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := backend.CompleteTask(context.Background(), t); err != nil {
+			return nonFatalErrorMsg{errorMessage: "Failed to complete task: " + err.Error()}
+		}
+		return taskUpdatedMsg{taskID: t.UID}
+	}
+}
+
+func deleteTask(uid string) tea.Cmd {
+	return func() tea.Msg {
+		if err := backend.DeleteTask(context.Background(), uid); err != nil {
+			return nonFatalErrorMsg{errorMessage: "Failed to delete task: " + err.Error()}
+		}
+		return taskUpdatedMsg{taskID: uid}
+	}
+}
+
+func addTask() tea.Msg {
+	return addTaskMsg{}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestSaveTask_RenameDoesNotTriggerReload(t *testing.T) {
+	original := backend
+	defer func() { backend = original }()
+
+	todo := &todoItem{UID: "1", Name: "old name"}
+	backend = &fakeBackend{todos: []*todoItem{todo}}
+
+	m := model{todos: []*todoItem{todo}}
+	msg := m.saveTask("1", "new name")()
+
+	if todo.Name != "new name" {
+		t.Errorf("Name = %q, want %q", todo.Name, "new name")
+	}
+
+	// A rename isn't persisted to the backend, so this must NOT be a
+	// taskUpdatedMsg: that triggers loadTasks, which would immediately
+	// re-fetch "old name" from the backend and undo the rename above.
+	if _, ok := msg.(taskUpdatedMsg); ok {
+		t.Error("saveTask returned taskUpdatedMsg for an unpersisted rename, which would wipe it out on reload")
+	}
+	if _, ok := msg.(nonFatalErrorMsg); !ok {
+		t.Errorf("saveTask returned %T, want nonFatalErrorMsg", msg)
+	}
+}
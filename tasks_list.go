@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tasksListDelegate renders todoItems in the tasks pane. It mirrors
+// eventsListDelegate but is simpler since tasks don't have conflicts or
+// response-status marks.
+type tasksListDelegate struct{}
+
+var _ list.ItemDelegate = (*tasksListDelegate)(nil)
+
+func (d *tasksListDelegate) Height() int  { return 1 }
+func (d *tasksListDelegate) Spacing() int { return 0 }
+
+func (d *tasksListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	t := item.(*todoItem)
+
+	line := t.String()
+	if index == m.Index() {
+		line = styles.SelectedTitle.Inline(true).Render(line)
+	} else if t.Completed {
+		line = styles.Declined.Inline(true).Render(line)
+	}
+
+	fmt.Fprint(w, line)
+}
+
+func (d *tasksListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+// handleTasksPaneKeyMsg handles key presses while the tasks pane is active,
+// mirroring handleDefaultModeKeyMsg's event-pane counterparts.
+func (m model) handleTasksPaneKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, appKeys.createTask):
+		return m, addTask
+
+	case key.Matches(msg, appKeys.editTask):
+		t, ok := m.selectedTask()
+		if !ok {
+			return m, nil
+		}
+		return m, func() tea.Msg { return addTaskMsg{taskID: t.UID} }
+
+	case key.Matches(msg, appKeys.completeTask):
+		t, ok := m.selectedTask()
+		if !ok {
+			return m, nil
+		}
+		return m, completeTask(t)
+
+	case key.Matches(msg, appKeys.deleteTask):
+		t, ok := m.selectedTask()
+		if !ok {
+			return m, nil
+		}
+		return m, deleteTask(t.UID)
+	}
+
+	return m, nil
+}
+
+func (m model) selectedTask() (*todoItem, bool) {
+	t, ok := m.tasksList.SelectedItem().(*todoItem)
+	return t, ok
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// todoItem models a VTODO-style task, parallel to eventItem. Unlike events,
+// tasks are not tied to a single occurrence: Rule (when set) is expanded
+// against a given day to decide whether the task is due that day.
+type todoItem struct {
+	UID         string
+	Name        string
+	Description string
+	Priority    int // 1 (highest), 5 (normal) or 9 (lowest), following VTODO/iCalendar convention
+	DueDateTime time.Time
+	RRule       string        // RFC 5545 RRULE, empty for a one-off task
+	AlarmOffset time.Duration // how long before DueDateTime to alert, 0 disables the alarm
+	Completed   bool
+}
+
+func (t *todoItem) FilterValue() string {
+	return t.Name
+}
+
+func (t *todoItem) String() string {
+	mark := "☐"
+	if t.Completed {
+		mark = "☑"
+	}
+	return mark + " " + t.Name
+}
+
+// rule parses RRule, returning nil if the task doesn't repeat or the rule
+// is malformed (treated the same as "doesn't repeat").
+func (t *todoItem) rule() *rrule.RRule {
+	if t.RRule == "" {
+		return nil
+	}
+	r, err := rrule.StrToRRule(t.RRule)
+	if err != nil {
+		return nil
+	}
+	r.DTStart(t.DueDateTime)
+	return r
+}
+
+// occursOn reports whether the task is due on the given day, expanding
+// RRule when present.
+func (t *todoItem) occursOn(date time.Time) bool {
+	dayStart := truncateToDay(date)
+	dayEnd := dayStart.Add(day)
+
+	if r := t.rule(); r != nil {
+		occurrences := r.Between(dayStart, dayEnd, true)
+		return len(occurrences) > 0
+	}
+
+	return !t.DueDateTime.Before(dayStart) && t.DueDateTime.Before(dayEnd)
+}
+
+// nextOccurrence returns the next due date strictly after after, for
+// repeating tasks. It returns the zero time for one-off tasks.
+func (t *todoItem) nextOccurrence(after time.Time) time.Time {
+	r := t.rule()
+	if r == nil {
+		return time.Time{}
+	}
+	next := r.After(after, false)
+	return next
+}
+
+// filterTodosForDate returns, in Name order, the tasks due on date.
+func filterTodosForDate(todos []*todoItem, date time.Time) []*todoItem {
+	out := make([]*todoItem, 0, len(todos))
+	for _, t := range todos {
+		if t.occursOn(date) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTodoItem_OccursOn_OneOff(t *testing.T) {
+	due := time.Date(2024, time.March, 4, 15, 0, 0, 0, time.UTC)
+	todo := &todoItem{Name: "one-off", DueDateTime: due}
+
+	if !todo.occursOn(due) {
+		t.Error("expected the task to occur on its due date")
+	}
+	if todo.occursOn(due.Add(24 * time.Hour)) {
+		t.Error("expected the task not to occur the next day")
+	}
+}
+
+func TestTodoItem_OccursOn_NonUTCLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo tzdata unavailable: %v", err)
+	}
+
+	// time.Time.Truncate(24h) rounds to a multiple of 24h since the
+	// absolute (UTC) zero time, which lands at 09:00 JST rather than local
+	// midnight; occursOn must use truncateToDay instead to get this right.
+	// A task due at 10:00 JST falls after that bogus 09:00 boundary, so the
+	// buggy version would place it on the wrong calendar day entirely.
+	due := time.Date(2024, time.March, 4, 10, 0, 0, 0, tokyo) // 10:00 JST
+	todo := &todoItem{Name: "jst", DueDateTime: due}
+
+	march4 := time.Date(2024, time.March, 4, 0, 0, 0, 0, tokyo)
+	march3 := time.Date(2024, time.March, 3, 0, 0, 0, 0, tokyo)
+
+	if !todo.occursOn(march4) {
+		t.Error("expected the task to occur on its local due date")
+	}
+	if todo.occursOn(march3) {
+		t.Error("expected the task not to occur the previous local day")
+	}
+}
+
+func TestTodoItem_OccursOn_WeeklyRRule(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC) // Monday
+	todo := &todoItem{Name: "weekly", DueDateTime: start, RRule: "FREQ=WEEKLY;BYDAY=MO"}
+
+	if !todo.occursOn(start) {
+		t.Error("expected the task to occur on its first Monday")
+	}
+	if !todo.occursOn(start.AddDate(0, 0, 7)) {
+		t.Error("expected the task to occur the following Monday")
+	}
+	if todo.occursOn(start.AddDate(0, 0, 1)) {
+		t.Error("expected the task not to occur on a Tuesday")
+	}
+}
+
+func TestTodoItem_NextOccurrence(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	todo := &todoItem{Name: "weekly", DueDateTime: start, RRule: "FREQ=WEEKLY;BYDAY=MO"}
+
+	next := todo.nextOccurrence(start)
+	want := start.AddDate(0, 0, 7)
+	if !next.Equal(want) {
+		t.Errorf("expected next occurrence %s, got %s", want, next)
+	}
+}
+
+func TestFilterTodosForDate(t *testing.T) {
+	base := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	a := &todoItem{Name: "a", DueDateTime: base}
+	b := &todoItem{Name: "b", DueDateTime: base.AddDate(0, 0, 1)}
+
+	due := filterTodosForDate([]*todoItem{a, b}, base)
+	if len(due) != 1 || due[0] != a {
+		t.Fatalf("expected only task a to be due, got %+v", due)
+	}
+}
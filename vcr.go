@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/motemen/go-nuts/oauth2util"
+	"gopkg.in/dnaeon/go-vcr.v2/cassette"
+	"gopkg.in/dnaeon/go-vcr.v2/recorder"
+)
+
+// redactedHeaders are stripped from both the request and the response side
+// of every interaction before it's written to (or matched against) a
+// cassette, so a recorded fixture never carries a live credential.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// createOAuth2Client returns an http.Client for oauth2Config. Outside test
+// mode it performs the real OAuth2 flow via oauth2Config.CreateOAuth2Client.
+// In test mode it instead wraps a go-vcr recorder around cassetteFile:
+// recording new interactions the first time (cassetteFile doesn't exist yet)
+// and replaying them deterministically every time after, via a custom
+// matcher and a pair of redacting filters so the cassette is safe to commit.
+func createOAuth2Client(oauth2Config *oauth2util.Config, testMode bool, cassetteFile string) (*http.Client, error) {
+	if !testMode {
+		return oauth2Config.CreateOAuth2Client(context.Background())
+	}
+
+	// recorder.New appends ".yaml" to the name it's given, so strip one off
+	// cassetteFile (which callers pass, and write/remove, as a full path) to
+	// avoid a doubled extension.
+	rec, err := recorder.New(strings.TrimSuffix(cassetteFile, ".yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	rec.SetMatcher(matchCassetteRequest)
+	rec.AddFilter(redactInteraction)
+	rec.AddSaveFilter(redactInteraction)
+
+	return &http.Client{Transport: rec}, nil
+}
+
+// matchCassetteRequest matches a live request against a recorded one by
+// method, URL path, and a canonicalized query (access_token dropped, the
+// rest sorted by cassette.Request.URL's own query-string encoding), plus
+// a JSON-normalized body comparison for POST/PATCH. A token refreshed
+// between recording and replay, or a query/body whose field order merely
+// drifted, therefore still matches; go-vcr's default matcher requires an
+// exact URL string match and would reject both.
+func matchCassetteRequest(r *http.Request, i cassette.Request) bool {
+	if r.Method != i.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(i.URL)
+	if err != nil {
+		return false
+	}
+	if r.URL.Path != recordedURL.Path {
+		return false
+	}
+	if canonicalQuery(r.URL.Query()) != canonicalQuery(recordedURL.Query()) {
+		return false
+	}
+
+	if r.Method != http.MethodPost && r.Method != http.MethodPatch {
+		return true
+	}
+
+	return normalizeJSON(requestBody(r)) == normalizeJSON(i.Body)
+}
+
+// canonicalQuery drops access_token (expected to differ between a
+// recording and a later replay) and relies on url.Values.Encode always
+// emitting keys in sorted order.
+func canonicalQuery(q url.Values) string {
+	q.Del("access_token")
+	return q.Encode()
+}
+
+// requestBody reads r.Body for comparison and restores it so the real
+// RoundTripper further down the chain (the recorder itself, in replaying
+// mode, doesn't forward the request at all; in recording mode it does)
+// still sees an unconsumed body.
+func requestBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return ""
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return string(body)
+}
+
+// normalizeJSON re-marshals s so two JSON bodies that differ only in key
+// order or insignificant whitespace compare equal. A body that isn't
+// valid JSON (or is empty) is returned unchanged.
+func normalizeJSON(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
+
+// redactInteraction is installed as both an AddFilter (applied before a
+// replayed interaction is matched/returned) and an AddSaveFilter (applied
+// before a recorded interaction is written), stripping credentials from
+// headers and token fields from JSON bodies either way.
+func redactInteraction(i *cassette.Interaction) error {
+	for _, h := range redactedHeaders {
+		i.Request.Headers.Del(h)
+		i.Response.Headers.Del(h)
+	}
+
+	i.Request.Body = redactTokenFields(i.Request.Body)
+	i.Response.Body = redactTokenFields(i.Response.Body)
+
+	return nil
+}
+
+// redactTokenFields walks a JSON body (object or array, at any nesting
+// depth) and replaces any access_token/refresh_token field, the shape an
+// OAuth2 token exchange's request and response bodies take. A non-JSON or
+// empty body is returned unchanged.
+func redactTokenFields(body string) string {
+	if body == "" {
+		return body
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+
+	redactValue(v)
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if strings.EqualFold(k, "access_token") || strings.EqualFold(k, "refresh_token") {
+				val[k] = "REDACTED"
+				continue
+			}
+			redactValue(child)
+		}
+	case []interface{}:
+		for _, child := range val {
+			redactValue(child)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/dnaeon/go-vcr.v2/cassette"
+)
+
+// TestMatchCassetteRequest_ToleratesMutatedRequests exercises the
+// "record once, replay against a mutated request" scenario the request
+// asks for directly against the matcher, rather than through a real
+// recorder round-trip: a different access_token, reordered query params,
+// and reordered JSON body keys are all expected to still match what was
+// recorded, while a change to anything else (method, path, a non-token
+// query param, or the body's actual content) is expected not to.
+func TestMatchCassetteRequest_ToleratesMutatedRequests(t *testing.T) {
+	recorded := cassette.Request{
+		Method: "POST",
+		URL:    "https://www.googleapis.com/calendar/v3/calendars/primary/events?access_token=orig-token&key=abc",
+		Body:   `{"summary":"Standup","attendees":["a@example.com","b@example.com"]}`,
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		rawURL string
+		body   string
+		want   bool
+	}{
+		{
+			name:   "identical request",
+			method: "POST",
+			rawURL: recorded.URL,
+			body:   recorded.Body,
+			want:   true,
+		},
+		{
+			name:   "refreshed access_token still matches",
+			method: "POST",
+			rawURL: "https://www.googleapis.com/calendar/v3/calendars/primary/events?access_token=different-token&key=abc",
+			body:   recorded.Body,
+			want:   true,
+		},
+		{
+			name:   "reordered query params still match",
+			method: "POST",
+			rawURL: "https://www.googleapis.com/calendar/v3/calendars/primary/events?key=abc&access_token=different-token",
+			body:   recorded.Body,
+			want:   true,
+		},
+		{
+			name:   "reordered JSON body keys still match",
+			method: "POST",
+			rawURL: recorded.URL,
+			body:   `{"attendees":["a@example.com","b@example.com"],"summary":"Standup"}`,
+			want:   true,
+		},
+		{
+			name:   "different method doesn't match",
+			method: "PATCH",
+			rawURL: recorded.URL,
+			body:   recorded.Body,
+			want:   false,
+		},
+		{
+			name:   "different path doesn't match",
+			method: "POST",
+			rawURL: "https://www.googleapis.com/calendar/v3/calendars/other/events?access_token=orig-token&key=abc",
+			body:   recorded.Body,
+			want:   false,
+		},
+		{
+			name:   "different non-token query param doesn't match",
+			method: "POST",
+			rawURL: "https://www.googleapis.com/calendar/v3/calendars/primary/events?access_token=orig-token&key=different",
+			body:   recorded.Body,
+			want:   false,
+		},
+		{
+			name:   "different body content doesn't match",
+			method: "POST",
+			rawURL: recorded.URL,
+			body:   `{"summary":"Something else","attendees":["a@example.com","b@example.com"]}`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.rawURL, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("NewRequest: %v", err)
+			}
+
+			if got := matchCassetteRequest(req, recorded); got != tt.want {
+				t.Errorf("matchCassetteRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactInteraction(t *testing.T) {
+	i := &cassette.Interaction{
+		Request: cassette.Request{
+			Headers: http.Header{
+				"Authorization": {"Bearer secret-access-token"},
+				"User-Agent":    {"gcal-tui/test"},
+			},
+			Body: `{"grant_type":"refresh_token","refresh_token":"super-secret-refresh","client_id":"abc"}`,
+		},
+		Response: cassette.Response{
+			Headers: http.Header{
+				"Set-Cookie":   {"session=secret"},
+				"Content-Type": {"application/json"},
+			},
+			Body: `{"access_token":"super-secret-access","token_type":"Bearer"}`,
+		},
+	}
+
+	if err := redactInteraction(i); err != nil {
+		t.Fatalf("redactInteraction: %v", err)
+	}
+
+	if i.Request.Headers.Get("Authorization") != "" {
+		t.Error("expected Authorization header to be redacted")
+	}
+	if i.Response.Headers.Get("Set-Cookie") != "" {
+		t.Error("expected Set-Cookie header to be redacted")
+	}
+	if strings.Contains(i.Request.Body, "super-secret-refresh") {
+		t.Error("expected refresh_token to be redacted from the request body")
+	}
+	if strings.Contains(i.Response.Body, "super-secret-access") {
+		t.Error("expected access_token to be redacted from the response body")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchPollInterval is how often the TUI polls the backend's incremental
+// sync API for changes to the currently loaded day, independent of
+// alarmTick's cadence since an event change can itself affect its alarms.
+const watchPollInterval = 20 * time.Second
+
+// watchTickMsg drives the recurring background-sync poll; Update rearms it
+// every time it's handled, the same pattern alarmTick uses.
+type watchTickMsg time.Time
+
+func watchTick() tea.Cmd {
+	return tea.Tick(watchPollInterval, func(t time.Time) tea.Msg {
+		return watchTickMsg(t)
+	})
+}
+
+// eventsChangedMsg reports the result of a single incremental sync poll. A
+// nil events slice with fullResync false means nothing changed since the
+// last poll.
+type eventsChangedMsg struct {
+	events     []*eventItem
+	fullResync bool
+}
+
+// pollChanges asks the backend for events changed since the last stored
+// sync token and reports the result as an eventsChangedMsg. A 410 Gone
+// (expired token, reported by the backend as fullResync) is not treated as
+// an error: Update falls back to reloadEvents instead of merging a partial
+// delta against a token the server no longer recognizes.
+func (m model) pollChanges() tea.Cmd {
+	calendarID := backend.CalendarID()
+	return func() tea.Msg {
+		token := eventCacheDB.SyncToken(calendarID)
+
+		changed, nextToken, fullResync, err := backend.PollChanges(context.Background(), token)
+		if err != nil {
+			return nonFatalErrorMsg{errorMessage: "background sync: " + err.Error()}
+		}
+
+		if nextToken != "" {
+			_ = eventCacheDB.SetSyncToken(calendarID, nextToken)
+		}
+
+		return eventsChangedMsg{events: changed, fullResync: fullResync}
+	}
+}
+
+// applyEventChanges merges changed into m.events in place (matched by Id,
+// dropping anything whose Status is "cancelled") and re-renders
+// m.eventsList, restoring the previously selected event by Id so the
+// cursor doesn't jump when a background sync reorders or resizes the list.
+func (m model) applyEventChanges(changed []*eventItem) (model, tea.Cmd) {
+	selectedID := ""
+	if it, ok := m.eventsList.SelectedItem().(*eventItem); ok {
+		selectedID = it.Id
+	}
+
+	m.events = mergeEventChanges(m.events, changed)
+
+	cmd := m.eventsList.SetItems(m.visibleListItems())
+
+	if selectedID != "" {
+		for i, ev := range m.events {
+			if ev.Id == selectedID {
+				m.eventsList.Select(i)
+				break
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+// mergeEventChanges returns events with changed applied on top: an event
+// already present (matched by Id) is replaced in place, a new one is
+// appended, and one whose Status is "cancelled" is removed.
+func mergeEventChanges(events, changed []*eventItem) []*eventItem {
+	merged := append([]*eventItem{}, events...)
+	index := make(map[string]int, len(merged))
+	for i, ev := range merged {
+		index[ev.Id] = i
+	}
+
+	for _, ev := range changed {
+		i, ok := index[ev.Id]
+		if ev.Status == "cancelled" {
+			if !ok {
+				continue
+			}
+			merged = append(merged[:i], merged[i+1:]...)
+			delete(index, ev.Id)
+			for id, idx := range index {
+				if idx > i {
+					index[id] = idx - 1
+				}
+			}
+			continue
+		}
+
+		if ok {
+			merged[i] = ev
+		} else {
+			index[ev.Id] = len(merged)
+			merged = append(merged, ev)
+		}
+	}
+
+	markConflicts(merged)
+	return merged
+}
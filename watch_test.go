@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMergeEventChanges(t *testing.T) {
+	base := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	events := []*eventItem{
+		{Event: &calendar.Event{Id: "1", Summary: "standup"}, Start: base, End: base.Add(30 * time.Minute)},
+		{Event: &calendar.Event{Id: "2", Summary: "1:1"}, Start: base.Add(time.Hour), End: base.Add(90 * time.Minute)},
+	}
+
+	changed := []*eventItem{
+		{Event: &calendar.Event{Id: "1", Summary: "standup (moved)"}, Start: base.Add(15 * time.Minute), End: base.Add(45 * time.Minute)},
+		{Event: &calendar.Event{Id: "2", Summary: "1:1", Status: "cancelled"}},
+		{Event: &calendar.Event{Id: "3", Summary: "new meeting"}, Start: base.Add(2 * time.Hour), End: base.Add(150 * time.Minute)},
+	}
+
+	merged := mergeEventChanges(events, changed)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 events after merge, got %d: %+v", len(merged), merged)
+	}
+	if merged[0].Id != "1" || merged[0].Summary != "standup (moved)" {
+		t.Fatalf("expected event 1 to be updated in place, got %+v", merged[0])
+	}
+	if merged[1].Id != "3" {
+		t.Fatalf("expected the new event to be appended, got %+v", merged[1])
+	}
+	for _, ev := range merged {
+		if ev.Id == "2" {
+			t.Fatalf("expected the cancelled event to be removed, got %+v", merged)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewMode selects how the events pane is rendered: the default single-day
+// list, or a columnar week/month grid built from weekEvents.
+type viewMode int
+
+const (
+	viewModeDay viewMode = iota
+	viewModeWeek
+	viewModeMonth
+)
+
+// weekEventsLoadedMsg carries the merged result of a week/month batch fetch,
+// keyed by the truncated start-of-day for each day in the range.
+type weekEventsLoadedMsg struct {
+	events map[time.Time][]*eventItem
+}
+
+// rangeFetchWorkers bounds how many per-day backend.List calls run at once,
+// so a month view doesn't open dozens of simultaneous requests.
+const rangeFetchWorkers = 4
+
+// nextWeekday returns the next day after d that isn't a Saturday or Sunday.
+func nextWeekday(d time.Time) time.Time {
+	d = d.Add(day)
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.Add(day)
+	}
+	return d
+}
+
+// prevWeekday returns the previous day before d that isn't a Saturday or
+// Sunday.
+func prevWeekday(d time.Time) time.Time {
+	d = d.Add(-day)
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.Add(-day)
+	}
+	return d
+}
+
+// weekDays returns the Monday-through-Sunday week containing d.
+func weekDays(d time.Time) []time.Time {
+	offset := int(d.Weekday()) - int(time.Monday)
+	if offset < 0 {
+		offset += 7
+	}
+	start := d.Add(-time.Duration(offset) * day)
+
+	days := make([]time.Time, 7)
+	for i := range days {
+		days[i] = start.Add(time.Duration(i) * day)
+	}
+	return days
+}
+
+// monthDays returns every day in d's calendar month.
+func monthDays(d time.Time) []time.Time {
+	first := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+	lastOfMonth := first.AddDate(0, 1, -1)
+
+	days := make([]time.Time, 0, 31)
+	for d := first; !d.After(lastOfMonth); d = d.Add(24 * time.Hour) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// rangeDays returns the days m.viewMode wants loaded/rendered around m.date.
+func (m model) rangeDays() []time.Time {
+	switch m.viewMode {
+	case viewModeMonth:
+		return monthDays(m.date)
+	default:
+		return weekDays(m.date)
+	}
+}
+
+// loadRangeEvents batches one backend.List call per day in m.rangeDays()
+// through a small worker pool and merges the results into a
+// map[time.Time][]*eventItem, so a week or month view loads in parallel
+// rather than one day at a time.
+func (m model) loadRangeEvents() tea.Cmd {
+	days := m.rangeDays()
+
+	return func() tea.Msg {
+		type result struct {
+			day    time.Time
+			events []*eventItem
+		}
+
+		jobs := make(chan time.Time)
+		results := make(chan result)
+
+		for w := 0; w < rangeFetchWorkers; w++ {
+			go func() {
+				for d := range jobs {
+					events, err := backend.List(context.Background(), d, d.Add(day))
+					if err != nil {
+						// Skip days we can't fetch; the grid simply shows
+						// them empty rather than failing the whole view.
+						events = nil
+					}
+					results <- result{day: d, events: events}
+				}
+			}()
+		}
+
+		go func() {
+			for _, d := range days {
+				jobs <- d
+			}
+			close(jobs)
+		}()
+
+		merged := make(map[time.Time][]*eventItem, len(days))
+		for range days {
+			r := <-results
+			merged[r.day] = r.events
+		}
+
+		return weekEventsLoadedMsg{events: merged}
+	}
+}
+
+// renderRangeView draws the week/month grid: one column per day, with a
+// header (weekday + date, highlighted for the selected day) and the day's
+// events below it, styled the same as the single-day list (accepted,
+// declined, conflicting).
+func (m model) renderRangeView() string {
+	days := m.rangeDays()
+
+	columnWidth := 20
+	if m.viewMode == viewModeMonth {
+		columnWidth = 12
+	}
+
+	columns := make([]string, len(days))
+	for i, d := range days {
+		columns[i] = m.renderRangeColumn(d, columnWidth)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+var (
+	rangeHeaderStyle         = lipgloss.NewStyle().Bold(true)
+	rangeSelectedHeaderStyle = rangeHeaderStyle.Copy().Foreground(lipgloss.Color("#50CFFA"))
+)
+
+func (m model) renderRangeColumn(d time.Time, width int) string {
+	header := d.Format("Mon 01-02")
+	headerStyle := rangeHeaderStyle
+	if d.Equal(truncateToDay(m.date)) {
+		headerStyle = rangeSelectedHeaderStyle
+	}
+
+	lines := []string{headerStyle.Width(width).Render(header)}
+
+	events := append([]*eventItem{}, m.weekEvents[d]...)
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	for _, ev := range events {
+		line := ev.Start.Format("15:04") + " " + ev.Summary
+		style := styles.NeedsAction
+		switch {
+		case ev.Declined():
+			style = styles.Declined
+		case ev.Accepted():
+			style = styles.Accepted
+		case len(ev.ConflictsWith) > 0:
+			style = styles.Conflict
+		}
+		lines = append(lines, style.Width(width).Render(truncate(line, width)))
+	}
+
+	return lipgloss.NewStyle().Width(width).Padding(0, 1, 0, 0).Render(
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+	)
+}
+
+func truncate(s string, width int) string {
+	if len(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return s[:width]
+	}
+	return s[:width-1] + "…"
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextWeekday(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"Thu to Fri", newDate(2024, time.March, 7), newDate(2024, time.March, 8)},
+		{"Fri to Mon", newDate(2024, time.March, 8), newDate(2024, time.March, 11)},
+		{"Sat to Mon", newDate(2024, time.March, 9), newDate(2024, time.March, 11)},
+		{"Sun to Mon", newDate(2024, time.March, 10), newDate(2024, time.March, 11)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextWeekday(tt.from); !got.Equal(tt.expected) {
+				t.Errorf("nextWeekday(%s) = %s, want %s", tt.from.Weekday(), got.Format("Mon 01-02"), tt.expected.Format("Mon 01-02"))
+			}
+		})
+	}
+}
+
+func TestPrevWeekday(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"Mon to Fri", newDate(2024, time.March, 11), newDate(2024, time.March, 8)},
+		{"Tue to Mon", newDate(2024, time.March, 12), newDate(2024, time.March, 11)},
+		{"Sun to Fri", newDate(2024, time.March, 10), newDate(2024, time.March, 8)},
+		{"Sat to Fri", newDate(2024, time.March, 9), newDate(2024, time.March, 8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prevWeekday(tt.from); !got.Equal(tt.expected) {
+				t.Errorf("prevWeekday(%s) = %s, want %s", tt.from.Weekday(), got.Format("Mon 01-02"), tt.expected.Format("Mon 01-02"))
+			}
+		})
+	}
+}
+
+func TestWeekDays(t *testing.T) {
+	days := weekDays(newDate(2024, time.March, 7)) // Thursday
+	if len(days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(days))
+	}
+	if !days[0].Equal(newDate(2024, time.March, 4)) || days[0].Weekday() != time.Monday {
+		t.Fatalf("expected the week to start on Monday 2024-03-04, got %s", days[0])
+	}
+	if !days[6].Equal(newDate(2024, time.March, 10)) || days[6].Weekday() != time.Sunday {
+		t.Fatalf("expected the week to end on Sunday 2024-03-10, got %s", days[6])
+	}
+}
+
+func TestMonthDays(t *testing.T) {
+	days := monthDays(newDate(2024, time.February, 15)) // leap year
+	if len(days) != 29 {
+		t.Fatalf("expected 29 days in February 2024, got %d", len(days))
+	}
+	if !days[0].Equal(newDate(2024, time.February, 1)) {
+		t.Fatalf("expected the first day to be 2024-02-01, got %s", days[0])
+	}
+	if !days[len(days)-1].Equal(newDate(2024, time.February, 29)) {
+		t.Fatalf("expected the last day to be 2024-02-29, got %s", days[len(days)-1])
+	}
+}